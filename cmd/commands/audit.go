@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/bogatyr285/auth-go/config"
+	"github.com/bogatyr285/auth-go/internal/auth/audit"
+	"github.com/spf13/cobra"
+)
+
+func NewAuditCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "audit",
+		Short: "Security audit log tools",
+	}
+	c.AddCommand(newAuditVerifyCmd())
+	return c
+}
+
+func newAuditVerifyCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify audit log hash chain and signed checkpoints end-to-end",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Parse(configPath)
+			if err != nil {
+				return err
+			}
+
+			storage, err := newStorage(cmd.Context(), cfg.Storage)
+			if err != nil {
+				return err
+			}
+			defer storage.Close()
+
+			jwtManager, err := newJWTManager(cfg.JWT)
+			if err != nil {
+				return err
+			}
+
+			report, err := audit.Verify(cmd.Context(), storage, jwtManager)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("audit log OK: %d events, %d checkpoints, head %d %s\n",
+				report.Events, report.Checkpoints, report.LastEventID, report.LastHash)
+			if report.Legacy > 0 {
+				cmd.Printf("%d events written before the hash chain existed were not checked\n", report.Legacy)
+			}
+			return nil
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
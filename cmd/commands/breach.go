@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/bogatyr285/auth-go/internal/pkg/breach"
+	"github.com/spf13/cobra"
+)
+
+func NewBreachCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "breach",
+		Short: "Breached passwords index tools",
+	}
+	c.AddCommand(newBreachBuildIndexCmd(), newBreachCheckCmd())
+	return c
+}
+
+func newBreachBuildIndexCmd() *cobra.Command {
+	var minCount int
+
+	c := &cobra.Command{
+		Use:   "build-index <pwned-passwords-sha1-ordered-by-hash.txt | -> <out.idx>",
+		Short: "Build lookup index from the Have I Been Pwned SHA-1 dump",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			// write next to the target and rename, so a running server never sees a partial file
+			tmp := args[1] + ".tmp"
+			out, err := os.Create(tmp)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(tmp)
+
+			n, err := breach.Build(in, out, minCount)
+			if err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Sync(); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+			if err := os.Rename(tmp, args[1]); err != nil {
+				return err
+			}
+
+			cmd.Printf("indexed %d hashes into %s\n", n, args[1])
+			return nil
+		},
+	}
+	c.Flags().IntVar(&minCount, "min-count", 1, "skip hashes seen less times than this")
+	return c
+}
+
+func newBreachCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check <index.idx> <password>",
+		Short: "Look up a password in the index",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index, err := breach.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer index.Close()
+
+			count, err := index.Count(args[1])
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				cmd.Println("not found")
+				return nil
+			}
+			cmd.Printf("found %d times\n", count)
+			return nil
+		},
+	}
+}
@@ -1 +1,150 @@
 package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bogatyr285/auth-go/config"
+	"github.com/bogatyr285/auth-go/internal/auth/repository"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database schema",
+		Long: `Migrations are embedded into the binary and tracked in the schema_migrations table.
+serve refuses to start until the database has all migrations of its build, so run "migrate up" before deploying.`,
+	}
+	c.AddCommand(newMigrateUpCmd(), newMigrateDownCmd(), newMigrateStatusCmd(), newMigrateGotoCmd())
+	return c
+}
+
+func newMigrateUpCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, configPath, func(m *repository.Migrator) error {
+				return m.Up(cmd.Context(), printMigration(cmd, "applied"))
+			})
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
+
+func newMigrateDownCmd() *cobra.Command {
+	var (
+		configPath string
+		steps      int
+	)
+
+	c := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the latest migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if steps < 1 {
+				return fmt.Errorf("steps must be positive")
+			}
+			return withMigrator(cmd, configPath, func(m *repository.Migrator) error {
+				return m.Down(cmd.Context(), steps, printMigration(cmd, "reverted"))
+			})
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	c.Flags().IntVar(&steps, "steps", 1, "how many migrations to revert")
+	return c
+}
+
+func newMigrateGotoCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "goto <version>",
+		Short: "Migrate up or down to the version, 0 reverts everything",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid version %q", args[0])
+			}
+			return withMigrator(cmd, configPath, func(m *repository.Migrator) error {
+				current, err := m.Version(cmd.Context())
+				if err != nil {
+					return err
+				}
+				action := "applied"
+				if version < current {
+					action = "reverted"
+				}
+				return m.Goto(cmd.Context(), version, printMigration(cmd, action))
+			})
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
+
+func newMigrateStatusCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(cmd, configPath, func(m *repository.Migrator) error {
+				statuses, err := m.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				for _, s := range statuses {
+					state := "pending"
+					if s.AppliedAt != nil {
+						state = "applied " + s.AppliedAt.Format(time.RFC3339)
+					}
+					cmd.Printf("%04d_%s\t%s\n", s.Version, s.Name, state)
+				}
+				return nil
+			})
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
+
+// withMigrator opens the database without the schema check newStorage does.
+func withMigrator(cmd *cobra.Command, configPath string, fn func(m *repository.Migrator) error) error {
+	cfg, err := config.Parse(configPath)
+	if err != nil {
+		return err
+	}
+
+	storage, err := openStorage(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	if err := fn(storage.Migrator()); err != nil {
+		return err
+	}
+	version, err := storage.Migrator().Version(cmd.Context())
+	if err != nil {
+		return err
+	}
+	cmd.Printf("database is at version %d\n", version)
+	return nil
+}
+
+func printMigration(cmd *cobra.Command, action string) func(repository.Migration) {
+	return func(m repository.Migration) {
+		cmd.Printf("%s %04d_%s\n", action, m.Version, m.Name)
+	}
+}
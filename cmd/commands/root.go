@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "auth",
+		Short: "Authentication service",
+	}
+	c.AddCommand(
+		NewServeCmd(),
+		NewMigrateCmd(),
+		NewUserCmd(),
+		NewAuditCmd(),
+		NewBreachCmd(),
+	)
+	return c
+}
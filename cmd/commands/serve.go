@@ -2,20 +2,33 @@ package commands
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/bogatyr285/auth-go/config"
+	"github.com/bogatyr285/auth-go/internal/auth/audit"
+	"github.com/bogatyr285/auth-go/internal/auth/policy"
 	"github.com/bogatyr285/auth-go/internal/auth/repository"
 	"github.com/bogatyr285/auth-go/internal/auth/usecase"
 	"github.com/bogatyr285/auth-go/internal/buildinfo"
 	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	httpmw "github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+	"github.com/bogatyr285/auth-go/internal/outbox"
+	"github.com/bogatyr285/auth-go/internal/pkg/breach"
 	"github.com/bogatyr285/auth-go/internal/pkg/crypto"
 	"github.com/bogatyr285/auth-go/internal/pkg/jwt"
+	"github.com/bogatyr285/auth-go/internal/pkg/notify"
+	"github.com/bogatyr285/auth-go/internal/pkg/signer"
+	"github.com/bogatyr285/auth-go/internal/webhook"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/spf13/cobra"
@@ -46,25 +59,88 @@ func NewServeCmd() *cobra.Command {
 			// TODO hide creds
 			slog.Info("loaded cfg", slog.Any("cfg", cfg))
 
-			storage, err := repository.New(cfg.Storage.SQLitePath)
+			trustedProxies, err := parseTrustedProxies(cfg.HTTPServer.TrustedProxies)
 			if err != nil {
 				return err
 			}
+			router.Use(httpmw.RealIP(trustedProxies))
+			router.Use(httpmw.ClientInfo)
 
-			passwordHasher := crypto.NewPasswordHasher()
-			jwtManager, err := jwt.NewJWTManager(
-				cfg.JWT.Issuer,
-				cfg.JWT.ExpiresIn,
-				[]byte(cfg.JWT.PublicKey),
-				[]byte(cfg.JWT.PrivateKey))
+			storage, err := newStorage(ctx, cfg.Storage)
 			if err != nil {
 				return err
 			}
 
-			useCase := usecase.NewUseCase(&storage,
+			passwordHasher, err := newPasswordHasher(cfg.Password)
+			if err != nil {
+				return err
+			}
+			jwtManager, err := newJWTManager(cfg.JWT)
+			if err != nil {
+				return err
+			}
+
+			tokens, err := newTokenIssuer(cfg.JWT.Format, jwtManager)
+			if err != nil {
+				return err
+			}
+
+			router.Use(httpmw.Authenticate(tokens))
+
+			passwordPolicy := policy.PasswordPolicy{MinLength: cfg.Policy.MinLength}
+			if cfg.Policy.BreachIndex != "" {
+				index, err := breach.Open(cfg.Policy.BreachIndex)
+				if err != nil {
+					return err
+				}
+				defer index.Close()
+				passwordPolicy.Breaches = index
+			}
+
+			opts := []usecase.Option{
+				usecase.WithLogger(log),
+				usecase.WithTokenIssuer(tokens),
+				usecase.WithImpersonationTTL(cfg.Admin.ImpersonationTTL),
+				usecase.WithPasswordPolicy(passwordPolicy),
+				usecase.WithPasswordRotation(cfg.Policy.History, cfg.Policy.MaxAge),
+				usecase.WithOrganizations(storage),
+				usecase.WithAuditLog(storage),
+			}
+			if cfg.MagicLink.Enabled {
+				var notifier usecase.Notifier = notify.NewLogNotifier(log)
+				if cfg.MagicLink.Notifier == "smtp" {
+					smtpCfg := cfg.MagicLink.SMTP
+					notifier = notify.NewSMTPNotifier(smtpCfg.Host, smtpCfg.Port, smtpCfg.Username, smtpCfg.Password, smtpCfg.From)
+				}
+				opts = append(opts, usecase.WithMagicLink(storage, notifier, cfg.MagicLink.URL, cfg.MagicLink.TTL))
+			}
+			if cfg.OAuth.TokenExchange.Enabled {
+				actors := make(map[string]usecase.ExchangeGrant, len(cfg.OAuth.TokenExchange.Actors))
+				for subject, actor := range cfg.OAuth.TokenExchange.Actors {
+					actors[subject] = usecase.ExchangeGrant{Audiences: actor.Audiences, Scopes: actor.Scopes}
+				}
+				opts = append(opts, usecase.WithTokenExchange(cfg.OAuth.TokenExchange.TTL, actors))
+			}
+			if device := cfg.OAuth.Device; device.Enabled {
+				opts = append(opts, usecase.WithDeviceAuthorization(storage, device.VerificationURL, device.TTL, device.Interval, cfg.JWT.ExpiresIn))
+			}
+			var dispatcher *webhook.Dispatcher
+			if cfg.Webhooks.Enabled {
+				dispatcher = webhook.NewDispatcher(storage, webhook.Config{
+					PollInterval: cfg.Webhooks.PollInterval,
+					Timeout:      cfg.Webhooks.Timeout,
+					MaxAttempts:  cfg.Webhooks.MaxAttempts,
+					BackoffBase:  cfg.Webhooks.BackoffBase,
+					BackoffMax:   cfg.Webhooks.BackoffMax,
+				}, log)
+				opts = append(opts, usecase.WithWebhooks(storage, dispatcher))
+			}
+
+			useCase := usecase.NewUseCase(storage,
 				passwordHasher,
 				jwtManager,
-				buildinfo.New())
+				buildinfo.New(),
+				opts...)
 
 			//// Добавление обработчика для генерации JWT
 			//router.Post("/auth/token", func(w http.ResponseWriter, r *http.Request) {
@@ -89,6 +165,24 @@ func NewServeCmd() *cobra.Command {
 				Handler:      gen.HandlerFromMux(gen.NewStrictHandler(useCase, nil), router),
 			}
 
+			checkpointer := audit.NewCheckpointer(storage, jwtManager, cfg.Audit.CheckpointInterval, log)
+			go checkpointer.Run(ctx)
+			if dispatcher != nil {
+				go dispatcher.Run(ctx)
+			}
+			if cfg.Outbox.Enabled {
+				var publisher outbox.Publisher = outbox.NewStdoutPublisher()
+				if cfg.Outbox.Publisher == "file" {
+					filePublisher, closer, err := outbox.NewFilePublisher(cfg.Outbox.Path)
+					if err != nil {
+						return err
+					}
+					defer closer.Close()
+					publisher = filePublisher
+				}
+				go outbox.NewRelay(storage, publisher, cfg.Outbox.PollInterval, log).Run(ctx)
+			}
+
 			go func() {
 				if err := httpServer.ListenAndServe(); err != nil {
 					log.Error("ListenAndServe", slog.Any("err", err))
@@ -102,6 +196,11 @@ func NewServeCmd() *cobra.Command {
 				log.Error("httpServer.Shutdown", slog.Any("err", err))
 			}
 
+			// sign whatever was logged since the last tick
+			if err := checkpointer.Checkpoint(closeCtx); err != nil {
+				log.Error("audit checkpoint", slog.Any("err", err))
+			}
+
 			if err := storage.Close(); err != nil {
 				log.Error("storage.Close", slog.Any("err", err))
 			}
@@ -112,3 +211,246 @@ func NewServeCmd() *cobra.Command {
 	c.Flags().StringVar(&configPath, "config", "", "path to config")
 	return c
 }
+
+// newJWTManager builds key ring from config, a single public_key/private_key pair is the ring of one key.
+func newJWTManager(cfg config.JWT) (*jwt.JWTManager, error) {
+	if err := checkAudience(cfg); err != nil {
+		return nil, err
+	}
+	opts := []jwt.Option{
+		jwt.WithAudience(cfg.Audience...),
+		jwt.WithValidation(jwt.Validation{
+			Issuer:         cfg.Validation.Issuer,
+			Audiences:      cfg.Validation.Audiences,
+			Leeway:         cfg.Validation.Leeway,
+			RequiredClaims: cfg.Validation.RequiredClaims,
+			MaxAge:         cfg.Validation.MaxAge,
+		}),
+	}
+	if cfg.Encryption.Enabled {
+		enc, err := newEncryption(cfg.Encryption)
+		if err != nil {
+			return nil, fmt.Errorf("jwt encryption key: %w", err)
+		}
+		enc.Required = cfg.Encryption.Required
+		opts = append(opts, jwt.WithEncryption(enc))
+	}
+	if len(cfg.Keys) == 0 {
+		key, err := newJWTKey("", cfg.Algorithm, cfg.PublicKey, cfg.PrivateKey, cfg.Vault)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := jwt.NewKeyRing(key)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewJWTManagerWithKeys(cfg.Issuer, cfg.ExpiresIn, keys, opts...), nil
+	}
+
+	var (
+		active   jwt.Key
+		previous []jwt.Key
+	)
+	for _, k := range cfg.Keys {
+		key, err := newJWTKey(k.ID, k.Algorithm, k.PublicKey, k.PrivateKey, cfg.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", k.ID, err)
+		}
+		key.VerifyUntil = k.VerifyUntil
+		if k.ID == cfg.ActiveKey {
+			active = key
+			continue
+		}
+		previous = append(previous, key)
+	}
+
+	keys, err := jwt.NewKeyRing(active, previous...)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.NewJWTManagerWithKeys(cfg.Issuer, cfg.ExpiresIn, keys, opts...), nil
+}
+
+func newEncryption(cfg config.JWTEncryption) (jwt.Encryption, error) {
+	if cfg.Algorithm == "" {
+		return jwt.ParseEncryptionKey(cfg.KeyID, []byte(cfg.PublicKey), []byte(cfg.PrivateKey))
+	}
+	key, err := base64.StdEncoding.DecodeString(cfg.SharedKey)
+	if err != nil {
+		return jwt.Encryption{}, fmt.Errorf("shared key is not base64: %w", err)
+	}
+	return jwt.NewSharedEncryption(cfg.KeyID, cfg.Algorithm, key)
+}
+
+// checkAudience makes sure the service accepts tokens it issues itself.
+func checkAudience(cfg config.JWT) error {
+	if len(cfg.Validation.Audiences) == 0 {
+		return nil
+	}
+	for _, aud := range cfg.Audience {
+		if slices.Contains(cfg.Validation.Audiences, aud) {
+			return nil
+		}
+	}
+	return fmt.Errorf("jwt.audience %v has none of jwt.validation.audiences %v, issued tokens would be rejected", cfg.Audience, cfg.Validation.Audiences)
+}
+
+// newJWTKey resolves private key source, public key is taken from the signer when not configured.
+func newJWTKey(id, algorithm, publicKeyPEM, privateKeySource string, vault config.Vault) (jwt.Key, error) {
+	priv, err := newSigner(privateKeySource, vault)
+	if err != nil {
+		return jwt.Key{}, err
+	}
+
+	if publicKeyPEM == "" {
+		if priv == nil {
+			return jwt.Key{}, fmt.Errorf("no public key")
+		}
+		return jwt.NewKey(id, algorithm, priv.Public(), priv)
+	}
+	pub, err := jwt.ParsePublicKey([]byte(publicKeyPEM))
+	if err != nil {
+		return jwt.Key{}, err
+	}
+	return jwt.NewKey(id, algorithm, pub, priv)
+}
+
+// newSigner - private key from PEM file, "env:VAR_NAME" or "vault:<transit key name>", nil for empty source.
+func newSigner(source string, vault config.Vault) (jwt.Signer, error) {
+	if source == "" {
+		return nil, nil
+	}
+	if name, ok := strings.CutPrefix(source, "env:"); ok {
+		return signer.FromEnv(name)
+	}
+	if key, ok := strings.CutPrefix(source, "vault:"); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), vault.Timeout)
+		defer cancel()
+		return signer.NewVaultTransit(ctx, signer.VaultConfig{
+			Address: vault.Address,
+			Token:   vault.Token,
+			Mount:   vault.Mount,
+			Key:     key,
+			Timeout: vault.Timeout,
+		})
+	}
+	return signer.FromFile(source)
+}
+
+// newStorage opens the configured database and makes sure its schema is migrated, the caller closes it.
+func newStorage(ctx context.Context, cfg config.Storage) (repository.Storage, error) {
+	storage, err := openStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.Migrator().Check(ctx); err != nil {
+		storage.Close()
+		return nil, err
+	}
+	return storage, nil
+}
+
+// openStorage opens the configured database as is.
+func openStorage(cfg config.Storage) (repository.Storage, error) {
+	pool := repository.Pool{
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
+	}
+	switch cfg.Driver {
+	case "sqlite":
+		s, err := repository.New(cfg.SQLitePath, repository.SQLiteOptions{
+			JournalMode: cfg.JournalMode,
+			BusyTimeout: cfg.BusyTimeout,
+			ForeignKeys: cfg.ForeignKeys,
+			Pool:        pool,
+		})
+		return &s, err
+	case "postgres":
+		if cfg.DSN == "" {
+			return nil, fmt.Errorf("storage dsn is not set")
+		}
+		s, err := repository.NewPostgres(cfg.DSN, pool)
+		return &s, err
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+}
+
+// newTokenIssuer selects format of access tokens, both use keys of the manager.
+func newTokenIssuer(format string, jwtManager *jwt.JWTManager) (jwt.TokenIssuer, error) {
+	switch format {
+	case "jwt":
+		return jwtManager, nil
+	case "paseto":
+		return jwt.NewPasetoIssuer(jwtManager)
+	}
+	return nil, fmt.Errorf("unknown token format %q", format)
+}
+
+// newPasswordHasher uses configured algorithm for new hashes and keeps the other one for verification,
+// along with formats of systems users were imported from.
+func newPasswordHasher(cfg config.Password) (crypto.PasswordHasher, error) {
+	argonParams := crypto.Argon2Params{
+		Memory:      cfg.Argon2id.Memory,
+		Iterations:  cfg.Argon2id.Iterations,
+		Parallelism: cfg.Argon2id.Parallelism,
+		SaltLength:  cfg.Argon2id.SaltLength,
+		KeyLength:   cfg.Argon2id.KeyLength,
+	}
+	// hashes made with parameters above the limits couldn't be verified later
+	if err := argonParams.Validate(); err != nil {
+		return crypto.PasswordHasher{}, err
+	}
+	argon := crypto.NewArgon2idHasher(argonParams)
+	bcrypt := crypto.NewBcryptHasher(cfg.Bcrypt.Cost)
+	imported := []crypto.Verifier{
+		crypto.PBKDF2Verifier{},
+		crypto.ScryptVerifier{},
+		crypto.SHA512CryptVerifier{},
+		crypto.DjangoVerifier{},
+	}
+
+	var hasher crypto.PasswordHasher
+	switch cfg.Algorithm {
+	case "argon2id":
+		hasher = crypto.NewPasswordHasher(argon, append([]crypto.Verifier{bcrypt}, imported...)...)
+	case "bcrypt":
+		hasher = crypto.NewPasswordHasher(bcrypt, append([]crypto.Verifier{argon}, imported...)...)
+	default:
+		return crypto.PasswordHasher{}, fmt.Errorf("unknown password hashing algorithm %q", cfg.Algorithm)
+	}
+
+	if len(cfg.Pepper.Keys) == 0 {
+		return hasher, nil
+	}
+	// with empty current the keys are kept for verification only
+	var current crypto.Pepper
+	if cfg.Pepper.Current != "" {
+		current = crypto.Pepper{Version: cfg.Pepper.Current, Key: []byte(cfg.Pepper.Keys[cfg.Pepper.Current])}
+	}
+	var previous []crypto.Pepper
+	for version, key := range cfg.Pepper.Keys {
+		if version != cfg.Pepper.Current {
+			previous = append(previous, crypto.Pepper{Version: version, Key: []byte(key)})
+		}
+	}
+	return hasher.WithPeppers(current, previous...)
+}
+
+// parseTrustedProxies accepts both single addresses and CIDRs.
+func parseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		if ip, err := netip.ParseAddr(p); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", p)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bogatyr285/auth-go/config"
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/spf13/cobra"
+)
+
+func NewUserCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+	c.AddCommand(newUserSetRoleCmd())
+	c.AddCommand(newUserSetStatusCmd())
+	c.AddCommand(newUserImportCmd())
+	return c
+}
+
+func newUserSetRoleCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "set-role <username> <role>",
+		Short: "Change user role (user | admin)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			username, role := args[0], args[1]
+			if role != entity.RoleUser && role != entity.RoleAdmin {
+				return fmt.Errorf("unknown role %q", role)
+			}
+
+			cfg, err := config.Parse(configPath)
+			if err != nil {
+				return err
+			}
+
+			storage, err := newStorage(cmd.Context(), cfg.Storage)
+			if err != nil {
+				return err
+			}
+			defer storage.Close()
+
+			if err := storage.SetUserRole(cmd.Context(), username, role); err != nil {
+				return fmt.Errorf("set role for %s: %w", username, err)
+			}
+			cmd.Printf("%s is now %s\n", username, role)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
+
+func newUserSetStatusCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "set-status <username> <status>",
+		Short: "Enable or disable user account (active | disabled)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			username, status := args[0], args[1]
+			if status != entity.UserStatusActive && status != entity.UserStatusDisabled {
+				return fmt.Errorf("unknown status %q", status)
+			}
+
+			cfg, err := config.Parse(configPath)
+			if err != nil {
+				return err
+			}
+
+			storage, err := newStorage(cmd.Context(), cfg.Storage)
+			if err != nil {
+				return err
+			}
+			defer storage.Close()
+
+			if err := storage.SetUserStatus(cmd.Context(), username, status); err != nil {
+				return fmt.Errorf("set status for %s: %w", username, err)
+			}
+			cmd.Printf("%s is now %s\n", username, status)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
+
+// importedUser - line of the import file
+type importedUser struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+	Role         string `json:"role"`
+}
+
+func newUserImportCmd() *cobra.Command {
+	var configPath string
+
+	c := &cobra.Command{
+		Use:   "import <file.jsonl | ->",
+		Short: "Bulk import users keeping their password hashes",
+		Long: `Reads JSON lines {"username": "...", "password_hash": "...", "role": "user"}.
+Supported hashes: argon2id, bcrypt, passlib pbkdf2-sha256 and scrypt, sha512-crypt ($6$),
+Django pbkdf2_sha256/scrypt/argon2. They are replaced with the configured algorithm on the user's next login.
+Existing usernames and unsupported hashes are skipped. Imported users aren't announced
+with user.registered events, they aren't new accounts.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Parse(configPath)
+			if err != nil {
+				return err
+			}
+			hasher, err := newPasswordHasher(cfg.Password)
+			if err != nil {
+				return err
+			}
+
+			var in io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			storage, err := newStorage(cmd.Context(), cfg.Storage)
+			if err != nil {
+				return err
+			}
+			defer storage.Close()
+
+			ctx := cmd.Context()
+			var imported, skipped int
+			scanner := bufio.NewScanner(in)
+			for line := 1; scanner.Scan(); line++ {
+				if len(scanner.Bytes()) == 0 {
+					continue
+				}
+				var u importedUser
+				if err := json.Unmarshal(scanner.Bytes(), &u); err != nil {
+					return fmt.Errorf("line %d: %w", line, err)
+				}
+				if u.Role == "" {
+					u.Role = entity.RoleUser
+				}
+
+				switch {
+				case u.Username == "":
+					cmd.PrintErrf("line %d: empty username, skipped\n", line)
+				case u.Role != entity.RoleUser && u.Role != entity.RoleAdmin:
+					cmd.PrintErrf("line %d: %s: unknown role %q, skipped\n", line, u.Username, u.Role)
+				case !hasher.Supports(u.PasswordHash):
+					cmd.PrintErrf("line %d: %s: unsupported password hash, skipped\n", line, u.Username)
+				default:
+					_, err := storage.FindUserByEmail(ctx, u.Username)
+					if err == nil {
+						cmd.PrintErrf("line %d: %s already exists, skipped\n", line, u.Username)
+						break
+					}
+					if !errors.Is(err, entity.ErrNotFound) {
+						return err
+					}
+					account := entity.NewUserAccount(u.Username, u.PasswordHash)
+					account.Role = u.Role
+					_, err = storage.ImportUser(ctx, account)
+					if err != nil {
+						return fmt.Errorf("line %d: %s: %w", line, u.Username, err)
+					}
+					imported++
+					continue
+				}
+				skipped++
+			}
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+
+			cmd.Printf("imported %d users, skipped %d\n", imported, skipped)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&configPath, "config", "", "path to config")
+	return c
+}
@@ -10,7 +10,7 @@ import (
 func main() {
 	ctx := context.Background()
 
-	cmd := commands.NewServeCmd()
+	cmd := commands.NewRootCmd()
 
 	if err := cmd.ExecuteContext(ctx); err != nil {
 		log.Fatalf("smth went wrong: %s", err)
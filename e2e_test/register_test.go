@@ -15,11 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const (
-	testServerPort = ":18082"
-	dbDir          = "."
-	dbFileName     = "test_db.sql"
-)
+const testServerPort = ":18082"
 
 type RegisterUserRequest struct {
 	Username string `json:"username"`
@@ -28,7 +24,7 @@ type RegisterUserRequest struct {
 
 type RegisterUserResponse struct {
 	Username string `json:"username"`
-	ID       int    `json:"id"`
+	ID       int64  `json:"id"`
 }
 
 // Пример e2e теста
@@ -40,29 +36,34 @@ func TestRegisterUser(t *testing.T) {
 	pubKeyPath, privKeyPath, err := createTempKeysInDir(tempDir)
 	require.NoError(t, err)
 
-	if _, err := os.Stat(dbDir); os.IsNotExist(err) {
-		err = os.Mkdir(dbDir, 0755)
-		require.NoError(t, err)
-	}
-
-	dbPath := filepath.Join(dbDir, dbFileName)
-
-	_ = os.Remove(dbPath)
+	// база вместе с файлами журнала WAL удаляется после теста
+	dbPath := filepath.Join(t.TempDir(), "test_db.sql")
 
 	configFile, err := createTempConfigFile(pubKeyPath, privKeyPath, dbPath)
 	require.NoError(t, err)
 	defer os.Remove(configFile.Name())
 
+	// Схему создает migrate up, сервер ее только проверяет
+	migrate := commands.NewMigrateCmd()
+	migrate.SetArgs([]string{"up", "--config", configFile.Name()})
+	require.NoError(t, migrate.Execute())
+
 	// Запускаем сервер в тесте
 	cmd := commands.NewServeCmd()
 	cmd.SetArgs([]string{"--config", configFile.Name()})
 
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	stopped := make(chan struct{})
+	// сервер должен закрыть базу до удаления временного каталога
+	defer func() {
+		cancel()
+		<-stopped
+	}()
 
 	go func() {
+		defer close(stopped)
 		if err := cmd.ExecuteContext(ctx); err != nil {
-			t.Fatalf("server execution failed: %v", err)
+			t.Errorf("server execution failed: %v", err)
 		}
 	}()
 
@@ -86,7 +87,7 @@ func TestRegisterUser(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, "testuser", registerResponse.Username)
-	//assert.NotZero(t, registerResponse.ID)
+	assert.NotZero(t, registerResponse.ID)
 }
 
 func createTempKeysInDir(dir string) (string, string, error) {
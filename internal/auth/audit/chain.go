@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+var (
+	ErrBrokenChain       = errors.New("audit chain is broken")
+	ErrInvalidCheckpoint = errors.New("audit checkpoint is invalid")
+)
+
+type ChainReader interface {
+	ExportAuditEvents(ctx context.Context, f entity.AuditFilter, fn func(entity.AuditEvent) error) error
+	ExportAuditCheckpoints(ctx context.Context, fn func(entity.AuditCheckpoint) error) error
+}
+
+type SignatureVerifier interface {
+	VerifyPayload(data, signature []byte) bool
+}
+
+// Report - what Verify has checked
+type Report struct {
+	// rows written before the upgrade that introduced the chain, they have no hashes
+	// and can't be checked; they may only precede the chain
+	Legacy      int
+	Events      int
+	Checkpoints int
+	LastEventID int64
+	LastHash    string
+}
+
+// Verify walks the whole log oldest first recomputing every hash, then checks that each
+// checkpoint is signed by us and points to an event which is still in the chain with the same hash.
+func Verify(ctx context.Context, r ChainReader, v SignatureVerifier) (Report, error) {
+	var report Report
+	// hashes of all events are needed to match checkpoints, 64 bytes per event is affordable
+	hashes := make(map[int64]string)
+
+	err := r.ExportAuditEvents(ctx, entity.AuditFilter{}, func(e entity.AuditEvent) error {
+		if report.Events == 0 && e.PrevHash == "" && e.Hash == "" {
+			report.Legacy++
+			return nil
+		}
+		if e.PrevHash != report.LastHash {
+			return fmt.Errorf("%w: event %d doesn't link to event %d", ErrBrokenChain, e.ID, report.LastEventID)
+		}
+		if e.Hash != e.ChainHash() {
+			return fmt.Errorf("%w: event %d was modified", ErrBrokenChain, e.ID)
+		}
+		hashes[e.ID] = e.Hash
+		report.Events++
+		report.LastEventID = e.ID
+		report.LastHash = e.Hash
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	err = r.ExportAuditCheckpoints(ctx, func(c entity.AuditCheckpoint) error {
+		if !v.VerifyPayload(c.SigningPayload(), c.Signature) && !v.VerifyPayload(c.LegacySigningPayload(), c.Signature) {
+			return fmt.Errorf("%w: checkpoint %d has bad signature", ErrInvalidCheckpoint, c.ID)
+		}
+		hash, ok := hashes[c.EventID]
+		if !ok {
+			return fmt.Errorf("%w: checkpoint %d points to missing event %d", ErrBrokenChain, c.ID, c.EventID)
+		}
+		if hash != c.Hash {
+			return fmt.Errorf("%w: checkpoint %d doesn't match event %d", ErrBrokenChain, c.ID, c.EventID)
+		}
+		report.Checkpoints++
+		return nil
+	})
+	return report, err
+}
@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Проверяем, что изменение, удаление записи или хвоста журнала обнаруживается
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys := testKeys{pub: pub, priv: priv}
+
+	tests := []struct {
+		name        string
+		tamper      func(s *memStore)
+		expectedErr error
+		legacy      int
+	}{
+		{
+			name:   "Intact log",
+			tamper: func(s *memStore) {},
+		},
+		{
+			name:        "Modified event",
+			tamper:      func(s *memStore) { s.events[1].Result = entity.AuditResultSuccess },
+			expectedErr: ErrBrokenChain,
+		},
+		{
+			name:        "Deleted event",
+			tamper:      func(s *memStore) { s.events = append(s.events[:1], s.events[2:]...) },
+			expectedErr: ErrBrokenChain,
+		},
+		{
+			name:        "Truncated tail",
+			tamper:      func(s *memStore) { s.events = s.events[:2] },
+			expectedErr: ErrBrokenChain,
+		},
+		{
+			name:        "Forged checkpoint",
+			tamper:      func(s *memStore) { s.checkpoints[0].Signature[0] ^= 0xff },
+			expectedErr: ErrInvalidCheckpoint,
+		},
+		{
+			name:        "Checkpoint time changed",
+			tamper:      func(s *memStore) { s.checkpoints[0].CreatedAt = s.checkpoints[0].CreatedAt.Add(-time.Hour) },
+			expectedErr: ErrInvalidCheckpoint,
+		},
+		{
+			name: "Checkpoint signed before v2",
+			tamper: func(s *memStore) {
+				s.checkpoints[0].Signature, _ = keys.SignPayload(s.checkpoints[0].LegacySigningPayload())
+			},
+		},
+		{
+			// записи, сделанные до появления цепочки, после обновления остаются без хешей
+			name: "Legacy rows before the chain",
+			tamper: func(s *memStore) {
+				legacy := []entity.AuditEvent{{ID: -2, Actor: "old1"}, {ID: -1, Actor: "old2"}}
+				s.events = append(legacy, s.events...)
+			},
+			legacy: 2,
+		},
+		{
+			name: "Unchained row inside the chain",
+			tamper: func(s *memStore) {
+				s.events[1].PrevHash, s.events[1].Hash = "", ""
+			},
+			expectedErr: ErrBrokenChain,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memStore{}
+			for i := 0; i < 3; i++ {
+				store.append(entity.AuditEvent{
+					OccurredAt: time.Now(),
+					Actor:      fmt.Sprintf("user%d", i),
+					Action:     entity.AuditLogin,
+					Result:     entity.AuditResultFailure,
+				})
+			}
+			require.NoError(t, NewCheckpointer(store, keys, time.Hour, nil).Checkpoint(context.Background()))
+
+			tt.tamper(store)
+
+			report, err := Verify(context.Background(), store, keys)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 3, report.Events)
+			assert.Equal(t, tt.legacy, report.Legacy)
+			assert.Equal(t, 1, report.Checkpoints)
+		})
+	}
+}
+
+type testKeys struct {
+	pub  ed25519.PublicKey
+	priv ed25519.PrivateKey
+}
+
+func (k testKeys) SignPayload(data []byte) ([]byte, error) {
+	return ed25519.Sign(k.priv, data), nil
+}
+
+func (k testKeys) VerifyPayload(data, signature []byte) bool {
+	return ed25519.Verify(k.pub, data, signature)
+}
+
+// memStore - хранилище в памяти, повторяющее логику SQLLiteStorage
+type memStore struct {
+	events      []entity.AuditEvent
+	checkpoints []entity.AuditCheckpoint
+}
+
+func (s *memStore) append(e entity.AuditEvent) {
+	if len(s.events) > 0 {
+		e.PrevHash = s.events[len(s.events)-1].Hash
+	}
+	e.ID = int64(len(s.events) + 1)
+	e.Hash = e.ChainHash()
+	s.events = append(s.events, e)
+}
+
+func (s *memStore) ExportAuditEvents(_ context.Context, _ entity.AuditFilter, fn func(entity.AuditEvent) error) error {
+	for _, e := range s.events {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) ExportAuditCheckpoints(_ context.Context, fn func(entity.AuditCheckpoint) error) error {
+	for _, c := range s.checkpoints {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) LastAuditEvent(context.Context) (entity.AuditEvent, error) {
+	if len(s.events) == 0 {
+		return entity.AuditEvent{}, entity.ErrNotFound
+	}
+	return s.events[len(s.events)-1], nil
+}
+
+func (s *memStore) LastAuditCheckpoint(context.Context) (entity.AuditCheckpoint, error) {
+	if len(s.checkpoints) == 0 {
+		return entity.AuditCheckpoint{}, entity.ErrNotFound
+	}
+	return s.checkpoints[len(s.checkpoints)-1], nil
+}
+
+func (s *memStore) SaveAuditCheckpoint(_ context.Context, c entity.AuditCheckpoint) error {
+	c.ID = int64(len(s.checkpoints) + 1)
+	s.checkpoints = append(s.checkpoints, c)
+	return nil
+}
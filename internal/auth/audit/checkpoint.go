@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+type CheckpointStore interface {
+	LastAuditEvent(ctx context.Context) (entity.AuditEvent, error)
+	LastAuditCheckpoint(ctx context.Context) (entity.AuditCheckpoint, error)
+	SaveAuditCheckpoint(ctx context.Context, c entity.AuditCheckpoint) error
+}
+
+type Signer interface {
+	SignPayload(data []byte) ([]byte, error)
+}
+
+// Checkpointer periodically signs the head of the audit chain.
+type Checkpointer struct {
+	store    CheckpointStore
+	signer   Signer
+	interval time.Duration
+	log      *slog.Logger
+}
+
+func NewCheckpointer(store CheckpointStore, signer Signer, interval time.Duration, log *slog.Logger) *Checkpointer {
+	return &Checkpointer{
+		store:    store,
+		signer:   signer,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run blocks until ctx is done, checkpoint is taken on every tick if there are new events.
+func (c *Checkpointer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Checkpoint(ctx); err != nil {
+				c.log.Error("audit checkpoint", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// Checkpoint signs the current chain head unless it's already signed.
+func (c *Checkpointer) Checkpoint(ctx context.Context) error {
+	head, err := c.store.LastAuditEvent(ctx)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if head.Hash == "" {
+		// only rows from before the chain existed, nothing to sign yet
+		return nil
+	}
+
+	last, err := c.store.LastAuditCheckpoint(ctx)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+	if err == nil && last.EventID == head.ID {
+		return nil
+	}
+
+	cp := entity.AuditCheckpoint{
+		EventID:   head.ID,
+		Hash:      head.Hash,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	cp.Signature, err = c.signer.SignPayload(cp.SigningPayload())
+	if err != nil {
+		return err
+	}
+	return c.store.SaveAuditCheckpoint(ctx, cp)
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+	// token has been issued, device code can't be used anymore
+	DeviceStatusConsumed = "consumed"
+)
+
+// DeviceAuthorization - RFC 8628 authorization request of a device without a browser, db schema.
+// Only hash of the device code is stored, it's the credential the device polls with.
+type DeviceAuthorization struct {
+	DeviceCodeHash string
+	UserCode       string
+	ClientID       string
+	Scope          string
+	Status         string
+	// user who approved or denied the request and organization their token was scoped to
+	Username string
+	Tenant   string
+	// minimum time between polls, grows when the device polls too fast
+	Interval     time.Duration
+	ExpiresAt    time.Time
+	LastPolledAt *time.Time
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "errors"
+
+var (
+	// ErrNotFound - requested record doesn't exist (or is no longer usable)
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists - unique constraint violation, e.g. organization slug is taken
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrWeakPassword - password rejected by the password policy, the message is safe to show to the user
+	ErrWeakPassword = errors.New("weak password")
+)
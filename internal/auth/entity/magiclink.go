@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// MagicLink - issued passwordless login link, db schema
+type MagicLink struct {
+	ID        string
+	Username  string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
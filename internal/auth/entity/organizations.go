@@ -0,0 +1,31 @@
+package entity
+
+const (
+	OrgRoleMember = "member"
+	OrgRoleAdmin  = "admin"
+
+	// RegistrationOpen - anyone may register into the organization
+	RegistrationOpen = "open"
+	// RegistrationClosed - only org admins add members
+	RegistrationClosed = "closed"
+)
+
+// Organization - tenant sharing the auth instance, db schema
+type Organization struct {
+	ID       int64
+	Slug     string
+	Name     string
+	Settings OrganizationSettings
+}
+
+// OrganizationSettings - per-org policies
+type OrganizationSettings struct {
+	RegistrationPolicy string
+}
+
+// Membership - user's role inside an organization, db schema
+type Membership struct {
+	OrgID    int64
+	Username string
+	Role     string
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent - domain event saved in the same transaction as the change it describes, db schema.
+// Relay publishes it to the message bus afterwards and sets PublishedAt.
+type OutboxEvent struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	PublishedAt *time.Time      `json:"-"`
+}
+
+// UserEventData - payload of user lifecycle events
+type UserEventData struct {
+	Username     string `json:"username"`
+	Organization string `json:"organization,omitempty"`
+}
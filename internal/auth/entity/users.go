@@ -1,10 +1,45 @@
 package entity
 
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+
+	UserStatusActive = "active"
+	// UserStatusDisabled - account is kept but can't log in
+	UserStatusDisabled = "disabled"
+)
+
 // UserAccount - db schema
 type UserAccount struct {
-	Username  string
-	Password  string
-	CreatedAt string
+	ID          int64
+	Username    string
+	Email       string
+	DisplayName string
+	Password    string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// nil until the first login after it was tracked
+	LastLoginAt *time.Time
+	// zero for accounts created before it was tracked
+	PasswordChangedAt time.Time
+}
+
+// NewUserAccount - active account with the default role.
+func NewUserAccount(username, passwordHash string) UserAccount {
+	return UserAccount{
+		Username: username,
+		Password: passwordHash,
+		Role:     RoleUser,
+		Status:   UserStatusActive,
+	}
+}
+
+func (u UserAccount) Active() bool {
+	return u.Status == UserStatusActive
 }
 
 type RegisterUserRequest struct {
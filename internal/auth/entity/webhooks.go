@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// user lifecycle events delivered to webhooks
+const (
+	EventUserRegistered      = "user.registered"
+	EventUserVerified        = "user.verified"
+	EventUserPasswordChanged = "user.password_changed"
+	EventUserDeleted         = "user.deleted"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription - external endpoint interested in some events, db schema
+type WebhookSubscription struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+func (s WebhookSubscription) Wants(event string) bool {
+	for _, e := range s.Events {
+		if e == event || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery - single event sent (or to be sent) to a subscription, db schema
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseCode   int
+	Error          string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,37 @@
+package policy
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// BreachChecker tells how many times password was seen in known breaches.
+type BreachChecker interface {
+	Count(password string) (int, error)
+}
+
+// PasswordPolicy validates new passwords on registration and password change.
+type PasswordPolicy struct {
+	MinLength int
+	// optional, e.g. breach.Index
+	Breaches BreachChecker
+}
+
+// Check returns error wrapping entity.ErrWeakPassword if password is not acceptable.
+func (p PasswordPolicy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters", entity.ErrWeakPassword, p.MinLength)
+	}
+	if p.Breaches != nil {
+		count, err := p.Breaches.Count(password)
+		if err != nil {
+			return fmt.Errorf("breach lookup: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: found in known data breaches, choose another one", entity.ErrWeakPassword)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// AppendAuditEvent links event to the last one in the chain and stores it.
+func (s *SQLLiteStorage) AppendAuditEvent(ctx context.Context, e entity.AuditEvent) error {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1`).Scan(&e.PrevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	e.OccurredAt = e.OccurredAt.UTC().Truncate(time.Microsecond)
+	e.Hash = e.ChainHash()
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO audit_events(occurred_at, actor, action, target, ip, user_agent, result, details, prev_hash, hash)
+	VALUES(?,?,?,?,?,?,?,?,?,?)`,
+		e.OccurredAt, e.Actor, e.Action, e.Target, e.IP, e.UserAgent, e.Result, e.Details, e.PrevHash, e.Hash)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LastAuditEvent returns the head of the chain, entity.ErrNotFound if the log is empty.
+func (s *SQLLiteStorage) LastAuditEvent(ctx context.Context) (entity.AuditEvent, error) {
+	var e entity.AuditEvent
+	err := s.db.QueryRowContext(ctx, `SELECT `+auditColumns+` FROM audit_events ORDER BY id DESC LIMIT 1`).
+		Scan(auditScanDest(&e)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.AuditEvent{}, entity.ErrNotFound
+	}
+	return e, err
+}
+
+func (s *SQLLiteStorage) SaveAuditCheckpoint(ctx context.Context, c entity.AuditCheckpoint) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO audit_checkpoints(event_id, hash, signature, created_at) VALUES(?,?,?,?)`,
+		c.EventID, c.Hash, c.Signature, c.CreatedAt.UTC())
+	return err
+}
+
+// LastAuditCheckpoint returns the most recent checkpoint, entity.ErrNotFound if there is none yet.
+func (s *SQLLiteStorage) LastAuditCheckpoint(ctx context.Context) (entity.AuditCheckpoint, error) {
+	var c entity.AuditCheckpoint
+	err := s.db.QueryRowContext(ctx, `SELECT id, event_id, hash, signature, created_at FROM audit_checkpoints ORDER BY id DESC LIMIT 1`).
+		Scan(&c.ID, &c.EventID, &c.Hash, &c.Signature, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.AuditCheckpoint{}, entity.ErrNotFound
+	}
+	return c, err
+}
+
+func (s *SQLLiteStorage) ExportAuditCheckpoints(ctx context.Context, fn func(entity.AuditCheckpoint) error) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, event_id, hash, signature, created_at FROM audit_checkpoints ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c entity.AuditCheckpoint
+		if err := rows.Scan(&c.ID, &c.EventID, &c.Hash, &c.Signature, &c.CreatedAt); err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ListAuditEvents returns matching events newest first.
+func (s *SQLLiteStorage) ListAuditEvents(ctx context.Context, f entity.AuditFilter) ([]entity.AuditEvent, error) {
+	var events []entity.AuditEvent
+	err := s.queryAuditEvents(ctx, f, "DESC", func(e entity.AuditEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	return events, err
+}
+
+// ExportAuditEvents streams matching events oldest first without loading them all into memory.
+func (s *SQLLiteStorage) ExportAuditEvents(ctx context.Context, f entity.AuditFilter, fn func(entity.AuditEvent) error) error {
+	return s.queryAuditEvents(ctx, f, "ASC", fn)
+}
+
+const auditColumns = `id, occurred_at, actor, action, target, ip, user_agent, result, details, prev_hash, hash`
+
+func auditScanDest(e *entity.AuditEvent) []interface{} {
+	return []interface{}{&e.ID, &e.OccurredAt, &e.Actor, &e.Action, &e.Target, &e.IP, &e.UserAgent, &e.Result, &e.Details, &e.PrevHash, &e.Hash}
+}
+
+func (s *SQLLiteStorage) queryAuditEvents(ctx context.Context, f entity.AuditFilter, order string, fn func(entity.AuditEvent) error) error {
+	query, args := auditQuery(f, order, func(int) string { return "?" })
+	return scanAuditEvents(ctx, s.db, query, args, fn)
+}
+
+// auditQuery builds filtered select, placeholder returns the driver's bind parameter for the n-th argument.
+func auditQuery(f entity.AuditFilter, order string, placeholder func(n int) string) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	bind := func(cond string, value interface{}) {
+		args = append(args, value)
+		conds = append(conds, cond+placeholder(len(args)))
+	}
+	for _, c := range []struct {
+		column string
+		value  string
+	}{
+		{"actor", f.Actor},
+		{"action", f.Action},
+		{"target", f.Target},
+		{"result", f.Result},
+	} {
+		if c.value != "" {
+			bind(c.column+" = ", c.value)
+		}
+	}
+	if !f.Since.IsZero() {
+		bind("occurred_at >= ", f.Since.UTC())
+	}
+	if !f.Until.IsZero() {
+		bind("occurred_at < ", f.Until.UTC())
+	}
+
+	query := `SELECT ` + auditColumns + ` FROM audit_events`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY id " + order
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += " LIMIT " + placeholder(len(args))
+	}
+	return query, args
+}
+
+func scanAuditEvents(ctx context.Context, db *sql.DB, query string, args []interface{}, fn func(entity.AuditEvent) error) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e entity.AuditEvent
+		if err := rows.Scan(auditScanDest(&e)...); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
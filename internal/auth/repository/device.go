@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+const deviceColumns = `device_code_hash, user_code, client_id, scope, status, username, tenant, interval_seconds, expires_at, last_polled_at`
+
+// SaveDeviceAuthorization stores new request, expired ones are removed on the way so their user codes can be reused.
+// Code taken by a pending request is entity.ErrAlreadyExists.
+func (s *SQLLiteStorage) SaveDeviceAuthorization(ctx context.Context, d entity.DeviceAuthorization) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM device_authorizations WHERE expires_at <= ?`, time.Now().UTC()); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO device_authorizations(device_code_hash, user_code, client_id, scope, status, interval_seconds, expires_at)
+		VALUES(?,?,?,?,?,?,?)`,
+		d.DeviceCodeHash, d.UserCode, d.ClientID, d.Scope, entity.DeviceStatusPending, int(d.Interval.Seconds()), d.ExpiresAt.UTC())
+	if isSQLiteUniqueViolation(err) {
+		return entity.ErrAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindDeviceAuthorization returns request waiting for the user decision, decided and expired ones are entity.ErrNotFound.
+func (s *SQLLiteStorage) FindDeviceAuthorization(ctx context.Context, userCode string) (entity.DeviceAuthorization, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+deviceColumns+` FROM device_authorizations
+		WHERE user_code = ? AND status = ? AND expires_at > ?`,
+		userCode, entity.DeviceStatusPending, time.Now().UTC())
+	return scanDeviceAuthorization(row)
+}
+
+// DecideDeviceAuthorization saves approval or denial of a pending request.
+func (s *SQLLiteStorage) DecideDeviceAuthorization(ctx context.Context, userCode, status, username, tenant string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET status = ?, username = ?, tenant = ?
+		WHERE user_code = ? AND status = ? AND expires_at > ?`,
+		status, username, tenant, userCode, entity.DeviceStatusPending, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// PollDeviceAuthorization remembers the poll time and returns the request as it was before, including the previous poll time.
+func (s *SQLLiteStorage) PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (entity.DeviceAuthorization, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	defer tx.Rollback()
+
+	d, err := scanDeviceAuthorization(tx.QueryRowContext(ctx, `SELECT `+deviceColumns+` FROM device_authorizations WHERE device_code_hash = ?`, deviceCodeHash))
+	if err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE device_authorizations SET last_polled_at = ? WHERE device_code_hash = ?`, now.UTC(), deviceCodeHash); err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	return d, tx.Commit()
+}
+
+// SlowDownDevicePolling sets new minimum interval between polls.
+func (s *SQLLiteStorage) SlowDownDevicePolling(ctx context.Context, deviceCodeHash string, interval time.Duration) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET interval_seconds = ? WHERE device_code_hash = ?`,
+		int(interval.Seconds()), deviceCodeHash)
+	return err
+}
+
+// ConsumeDeviceAuthorization marks approved request as used, so only one token is issued per device code.
+// Requests that are not approved or already consumed are reported as entity.ErrNotFound.
+func (s *SQLLiteStorage) ConsumeDeviceAuthorization(ctx context.Context, deviceCodeHash string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET status = ? WHERE device_code_hash = ? AND status = ?`,
+		entity.DeviceStatusConsumed, deviceCodeHash, entity.DeviceStatusApproved)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func scanDeviceAuthorization(row *sql.Row) (entity.DeviceAuthorization, error) {
+	var (
+		d        entity.DeviceAuthorization
+		interval int
+		polledAt sql.NullTime
+	)
+	err := row.Scan(&d.DeviceCodeHash, &d.UserCode, &d.ClientID, &d.Scope, &d.Status, &d.Username, &d.Tenant, &interval, &d.ExpiresAt, &polledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.DeviceAuthorization{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	d.Interval = time.Duration(interval) * time.Second
+	if polledAt.Valid {
+		d.LastPolledAt = &polledAt.Time
+	}
+	return d, nil
+}
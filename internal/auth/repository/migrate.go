@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations
+var migrationsFS embed.FS
+
+// Migration - pair of NNNN_name.up.sql / NNNN_name.down.sql files of the dialect directory.
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus - migration and the time it was applied, nil if it's pending.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies embedded migrations. Every step runs in its own transaction together with
+// the schema_migrations update, so a failed migration leaves the database at the previous version.
+type Migrator struct {
+	db  *sql.DB
+	dir string
+	// placeholder of the n-th query argument
+	bind func(n int) string
+	// first statement of every step, serializes concurrent migrators; empty - not needed
+	lock        string
+	createTable string
+	tableExists string
+	// Go code run right after the migration of that version in the same transaction
+	after map[int]func(ctx context.Context, tx *sql.Tx) error
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migrations returns all known migrations ordered by version.
+func (m *Migrator) Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationsFS, m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		match := migrationFile.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", e.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(migrationsFS, path.Join(m.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, mig.Name, match[2])
+		}
+		if match[3] == "up" {
+			mig.up = string(body)
+		} else {
+			mig.down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.up == "" || mig.down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Version returns the version the database is at, 0 - nothing is applied yet.
+// Unlike the other methods it never changes the database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, m.tableExists).Scan(&exists); err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+	return currentVersion(ctx, m.db)
+}
+
+// Check fails if there are migrations the database lacks. Newer schema is fine,
+// it's what the previous release sees during a rolling update.
+func (m *Migrator) Check(ctx context.Context) error {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return err
+	}
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if latest := migrations[len(migrations)-1].Version; version < latest {
+		return fmt.Errorf("database schema is at version %d, latest is %d: run `migrate up`", version, latest)
+	}
+	return nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	applied := map[int]time.Time{}
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version > 0 {
+		rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var (
+				v  int
+				at time.Time
+			)
+			if err := rows.Scan(&v, &at); err != nil {
+				return nil, err
+			}
+			applied[v] = at
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, mig := range migrations {
+		statuses[i].Migration = mig
+		if at, ok := applied[mig.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context, fn func(Migration)) error {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return err
+	}
+	return m.Goto(ctx, migrations[len(migrations)-1].Version, fn)
+}
+
+// Down reverts steps latest migrations.
+func (m *Migrator) Down(ctx context.Context, steps int, fn func(Migration)) error {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return err
+	}
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	target := 0
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if migrations[i].Version > version {
+			continue
+		}
+		if steps == 0 {
+			target = migrations[i].Version
+			break
+		}
+		steps--
+	}
+	return m.Goto(ctx, target, fn)
+}
+
+// Goto migrates up or down to the version, 0 reverts everything. fn is called after each applied step.
+func (m *Migrator) Goto(ctx context.Context, target int, fn func(Migration)) error {
+	migrations, err := m.Migrations()
+	if err != nil {
+		return err
+	}
+	known := target == 0
+	for _, mig := range migrations {
+		known = known || mig.Version == target
+	}
+	if !known {
+		return fmt.Errorf("unknown migration version %d", target)
+	}
+
+	for {
+		mig, done, err := m.step(ctx, migrations, target)
+		if err != nil && mig.Version == 0 {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if done {
+			return nil
+		}
+		if fn != nil {
+			fn(mig)
+		}
+	}
+}
+
+// step moves the database one migration towards target. The version is read after taking the lock,
+// so concurrent migrators don't apply the same migration twice.
+func (m *Migrator) step(ctx context.Context, migrations []Migration, target int) (Migration, bool, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Migration{}, false, err
+	}
+	defer tx.Rollback()
+
+	if m.lock != "" {
+		if _, err := tx.ExecContext(ctx, m.lock); err != nil {
+			return Migration{}, false, err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, m.createTable); err != nil {
+		return Migration{}, false, err
+	}
+	version, err := currentVersion(ctx, tx)
+	if err != nil {
+		return Migration{}, false, err
+	}
+
+	switch {
+	case version < target:
+		for _, mig := range migrations {
+			if mig.Version <= version {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, mig.up); err != nil {
+				return mig, false, err
+			}
+			if after := m.after[mig.Version]; after != nil {
+				if err := after(ctx, tx); err != nil {
+					return mig, false, err
+				}
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations(version, name, applied_at) VALUES(`+m.bind(1)+`,`+m.bind(2)+`,`+m.bind(3)+`)`,
+				mig.Version, mig.Name, time.Now().UTC())
+			if err != nil {
+				return mig, false, err
+			}
+			return mig, false, tx.Commit()
+		}
+	case version > target:
+		for i := len(migrations) - 1; i >= 0; i-- {
+			mig := migrations[i]
+			if mig.Version > version {
+				continue
+			}
+			if mig.Version != version {
+				return mig, false, fmt.Errorf("database is at version %d which this build doesn't know", version)
+			}
+			if _, err := tx.ExecContext(ctx, mig.down); err != nil {
+				return mig, false, err
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = `+m.bind(1), mig.Version); err != nil {
+				return mig, false, err
+			}
+			return mig, false, tx.Commit()
+		}
+	}
+	return Migration{}, true, nil
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func currentVersion(ctx context.Context, q queryer) (int, error) {
+	var version sql.NullInt64
+	if err := q.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
@@ -0,0 +1,108 @@
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    username text not null,
+    password text not null,
+    role text not null default 'user',
+    password_changed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+create index if not exists idx_username ON users(username);
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id BIGSERIAL PRIMARY KEY,
+    username text not null,
+    password text not null,
+    created_at TIMESTAMPTZ not null);
+create index if not exists idx_password_history_username ON password_history(username, id);
+
+CREATE TABLE IF NOT EXISTS magic_links (
+    id text PRIMARY KEY,
+    username text not null,
+    expires_at TIMESTAMPTZ not null,
+    used_at TIMESTAMPTZ);
+
+CREATE TABLE IF NOT EXISTS device_authorizations (
+    device_code_hash text PRIMARY KEY,
+    user_code text not null unique,
+    client_id text not null,
+    scope text not null default '',
+    status text not null default 'pending',
+    username text not null default '',
+    tenant text not null default '',
+    interval_seconds INTEGER not null,
+    expires_at TIMESTAMPTZ not null,
+    last_polled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    slug text not null unique,
+    name text not null,
+    registration_policy text not null default 'closed',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP);
+
+CREATE TABLE IF NOT EXISTS memberships (
+    org_id BIGINT not null REFERENCES organizations(id) ON DELETE CASCADE,
+    username text not null,
+    role text not null default 'member',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (org_id, username));
+create index if not exists idx_memberships_username ON memberships(username);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ not null,
+    actor text not null default '',
+    action text not null,
+    target text not null default '',
+    ip text not null default '',
+    user_agent text not null default '',
+    result text not null,
+    details text not null default '',
+    prev_hash text not null default '',
+    hash text not null default '');
+create index if not exists idx_audit_events_actor ON audit_events(actor);
+create index if not exists idx_audit_events_action ON audit_events(action);
+create index if not exists idx_audit_events_occurred_at ON audit_events(occurred_at);
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN RAISE EXCEPTION 'audit_events is append-only'; END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TABLE IF NOT EXISTS audit_checkpoints (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT not null,
+    hash text not null,
+    signature BYTEA not null,
+    created_at TIMESTAMPTZ not null);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    url text not null,
+    secret text not null,
+    events text not null,
+    created_at TIMESTAMPTZ not null);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT not null REFERENCES webhook_subscriptions(id),
+    event text not null,
+    payload BYTEA not null,
+    status text not null,
+    attempts INTEGER not null default 0,
+    response_code INTEGER not null default 0,
+    error text not null default '',
+    next_attempt_at TIMESTAMPTZ not null,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ not null);
+create index if not exists idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+create index if not exists idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    type text not null,
+    payload BYTEA not null,
+    created_at TIMESTAMPTZ not null,
+    published_at TIMESTAMPTZ);
+create index if not exists idx_outbox_events_unpublished ON outbox_events(published_at, id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users
+    DROP COLUMN last_login_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN status,
+    DROP COLUMN display_name,
+    DROP COLUMN email;
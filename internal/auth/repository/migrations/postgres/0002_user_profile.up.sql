@@ -0,0 +1,8 @@
+ALTER TABLE users
+    ADD COLUMN email text not null default '',
+    ADD COLUMN display_name text not null default '',
+    ADD COLUMN status text not null default 'active',
+    ADD COLUMN updated_at TIMESTAMPTZ,
+    ADD COLUMN last_login_at TIMESTAMPTZ;
+UPDATE users SET updated_at = created_at;
+create index if not exists idx_users_email ON users(email);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_username;
+create index if not exists idx_username ON users(username);
@@ -0,0 +1,3 @@
+-- fails if the table already has duplicate usernames, they have to be merged by hand first
+DROP INDEX IF EXISTS idx_username;
+create unique index idx_username ON users(username);
@@ -0,0 +1,3 @@
+ALTER TABLE outbox_events
+    ADD COLUMN claimed_by text not null default '',
+    ADD COLUMN claimed_until TIMESTAMPTZ;
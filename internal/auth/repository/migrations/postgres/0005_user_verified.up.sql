@@ -0,0 +1,2 @@
+-- set when the user first follows a magic link, user.verified is published once
+ALTER TABLE users ADD COLUMN verified_at TIMESTAMPTZ;
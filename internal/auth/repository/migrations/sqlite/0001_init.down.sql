@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS outbox_events;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS audit_checkpoints;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS memberships;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS device_authorizations;
+DROP TABLE IF EXISTS magic_links;
+DROP TABLE IF EXISTS password_history;
+DROP TABLE IF EXISTS users;
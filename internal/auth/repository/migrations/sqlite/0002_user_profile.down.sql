@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users DROP COLUMN last_login_at;
+ALTER TABLE users DROP COLUMN updated_at;
+ALTER TABLE users DROP COLUMN status;
+ALTER TABLE users DROP COLUMN display_name;
+ALTER TABLE users DROP COLUMN email;
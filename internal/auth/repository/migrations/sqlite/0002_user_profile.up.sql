@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN email text not null default '';
+ALTER TABLE users ADD COLUMN display_name text not null default '';
+ALTER TABLE users ADD COLUMN status text not null default 'active';
+ALTER TABLE users ADD COLUMN updated_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN last_login_at TIMESTAMP;
+UPDATE users SET updated_at = created_at;
+create index if not exists idx_users_email ON users(email);
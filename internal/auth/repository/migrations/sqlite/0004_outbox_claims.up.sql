@@ -0,0 +1,3 @@
+-- claims are only taken by replicas sharing PostgreSQL, the columns keep both schemas alike
+ALTER TABLE outbox_events ADD COLUMN claimed_by text not null default '';
+ALTER TABLE outbox_events ADD COLUMN claimed_until TIMESTAMP;
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+func (s *SQLLiteStorage) CreateOrganization(ctx context.Context, o entity.Organization) (entity.Organization, error) {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO organizations(slug, name, registration_policy) VALUES(?,?,?)`,
+		o.Slug, o.Name, o.Settings.RegistrationPolicy)
+	if isSQLiteUniqueViolation(err) {
+		return entity.Organization{}, entity.ErrAlreadyExists
+	}
+	if err != nil {
+		return entity.Organization{}, err
+	}
+
+	o.ID, err = res.LastInsertId()
+	if err != nil {
+		return entity.Organization{}, err
+	}
+	return o, nil
+}
+
+func (s *SQLLiteStorage) FindOrganization(ctx context.Context, slug string) (entity.Organization, error) {
+	o := entity.Organization{Slug: slug}
+	err := s.db.QueryRowContext(ctx, `SELECT id, name, registration_policy FROM organizations WHERE slug = ?`, slug).
+		Scan(&o.ID, &o.Name, &o.Settings.RegistrationPolicy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Organization{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.Organization{}, err
+	}
+	return o, nil
+}
+
+func (s *SQLLiteStorage) UpdateOrganizationSettings(ctx context.Context, orgID int64, settings entity.OrganizationSettings) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE organizations SET registration_policy = ? WHERE id = ?`,
+		settings.RegistrationPolicy, orgID)
+	return err
+}
+
+// SaveMembership adds user to the organization or updates the role if they're already a member.
+func (s *SQLLiteStorage) SaveMembership(ctx context.Context, m entity.Membership) error {
+	_, err := s.db.ExecContext(ctx, `
+	INSERT INTO memberships(org_id, username, role) VALUES(?,?,?)
+	ON CONFLICT(org_id, username) DO UPDATE SET role = excluded.role`,
+		m.OrgID, m.Username, m.Role)
+	return err
+}
+
+func (s *SQLLiteStorage) FindMembership(ctx context.Context, orgID int64, username string) (entity.Membership, error) {
+	m := entity.Membership{OrgID: orgID, Username: username}
+	err := s.db.QueryRowContext(ctx, `SELECT role FROM memberships WHERE org_id = ? AND username = ?`, orgID, username).
+		Scan(&m.Role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Membership{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.Membership{}, err
+	}
+	return m, nil
+}
+
+func (s *SQLLiteStorage) ListMembers(ctx context.Context, orgID int64) ([]entity.Membership, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT username, role FROM memberships WHERE org_id = ? ORDER BY username`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []entity.Membership
+	for rows.Next() {
+		m := entity.Membership{OrgID: orgID}
+		if err := rows.Scan(&m.Username, &m.Role); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// insertOutboxEvent records event inside tx, so it's committed or rolled back together with the change.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO outbox_events(type, payload, created_at) VALUES(?,?,?)`,
+		eventType, payload, time.Now().UTC())
+	return err
+}
+
+// PendingOutboxEvents returns not yet published events in the order they were written.
+func (s *SQLLiteStorage) PendingOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, type, payload, created_at FROM outbox_events
+	WHERE published_at IS NULL ORDER BY id LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.OutboxEvent
+	for rows.Next() {
+		var e entity.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.Payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (s *SQLLiteStorage) MarkOutboxEventPublished(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE outbox_events SET published_at = ? WHERE id = ?`, at.UTC(), id)
+	return err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// ChangePassword replaces user password moving the old hash to the history,
+// only the latest keep entries of the history are retained.
+func (s *SQLLiteStorage) ChangePassword(ctx context.Context, username, hash string, keep int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var old string
+	err = tx.QueryRowContext(ctx, `SELECT password FROM users WHERE username = ?`, username).Scan(&old)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if keep > 0 {
+		_, err = tx.ExecContext(ctx, `INSERT INTO password_history(username, password, created_at) VALUES(?,?,?)`, username, old, now)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM password_history WHERE username = ? AND id NOT IN (
+		SELECT id FROM password_history WHERE username = ? ORDER BY id DESC LIMIT ?)`, username, username, keep)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users SET password = ?, password_changed_at = ?, updated_at = ? WHERE username = ?`, hash, now, now, username)
+	if err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, entity.EventUserPasswordChanged, entity.UserEventData{Username: username}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PasswordHistory returns previous password hashes, most recent first.
+func (s *SQLLiteStorage) PasswordHistory(ctx context.Context, username string, limit int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT password FROM password_history
+	WHERE username = ? ORDER BY id DESC LIMIT ?`, username, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
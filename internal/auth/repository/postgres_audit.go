@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// AppendAuditEvent links event to the last one in the chain and stores it.
+// Replicas append concurrently, so the chain head is guarded by an advisory lock instead of a mutex.
+func (s *PostgresStorage) AppendAuditEvent(ctx context.Context, e entity.AuditEvent) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(`+auditLockKey+`)`); err != nil {
+		return err
+	}
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1`).Scan(&e.PrevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	e.OccurredAt = e.OccurredAt.UTC().Truncate(time.Microsecond)
+	e.Hash = e.ChainHash()
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO audit_events(occurred_at, actor, action, target, ip, user_agent, result, details, prev_hash, hash)
+	VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`,
+		e.OccurredAt, e.Actor, e.Action, e.Target, e.IP, e.UserAgent, e.Result, e.Details, e.PrevHash, e.Hash)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LastAuditEvent returns the head of the chain, entity.ErrNotFound if the log is empty.
+func (s *PostgresStorage) LastAuditEvent(ctx context.Context) (entity.AuditEvent, error) {
+	var e entity.AuditEvent
+	err := s.db.QueryRowContext(ctx, `SELECT `+auditColumns+` FROM audit_events ORDER BY id DESC LIMIT 1`).
+		Scan(auditScanDest(&e)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.AuditEvent{}, entity.ErrNotFound
+	}
+	return e, err
+}
+
+func (s *PostgresStorage) SaveAuditCheckpoint(ctx context.Context, c entity.AuditCheckpoint) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO audit_checkpoints(event_id, hash, signature, created_at) VALUES($1,$2,$3,$4)`,
+		c.EventID, c.Hash, c.Signature, c.CreatedAt.UTC())
+	return err
+}
+
+// LastAuditCheckpoint returns the most recent checkpoint, entity.ErrNotFound if there is none yet.
+func (s *PostgresStorage) LastAuditCheckpoint(ctx context.Context) (entity.AuditCheckpoint, error) {
+	var c entity.AuditCheckpoint
+	err := s.db.QueryRowContext(ctx, `SELECT id, event_id, hash, signature, created_at FROM audit_checkpoints ORDER BY id DESC LIMIT 1`).
+		Scan(&c.ID, &c.EventID, &c.Hash, &c.Signature, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.AuditCheckpoint{}, entity.ErrNotFound
+	}
+	return c, err
+}
+
+func (s *PostgresStorage) ExportAuditCheckpoints(ctx context.Context, fn func(entity.AuditCheckpoint) error) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, event_id, hash, signature, created_at FROM audit_checkpoints ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c entity.AuditCheckpoint
+		if err := rows.Scan(&c.ID, &c.EventID, &c.Hash, &c.Signature, &c.CreatedAt); err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ListAuditEvents returns matching events newest first.
+func (s *PostgresStorage) ListAuditEvents(ctx context.Context, f entity.AuditFilter) ([]entity.AuditEvent, error) {
+	var events []entity.AuditEvent
+	err := s.queryAuditEvents(ctx, f, "DESC", func(e entity.AuditEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	return events, err
+}
+
+// ExportAuditEvents streams matching events oldest first without loading them all into memory.
+func (s *PostgresStorage) ExportAuditEvents(ctx context.Context, f entity.AuditFilter, fn func(entity.AuditEvent) error) error {
+	return s.queryAuditEvents(ctx, f, "ASC", fn)
+}
+
+func (s *PostgresStorage) queryAuditEvents(ctx context.Context, f entity.AuditFilter, order string, fn func(entity.AuditEvent) error) error {
+	query, args := auditQuery(f, order, func(n int) string { return "$" + strconv.Itoa(n) })
+	return scanAuditEvents(ctx, s.db, query, args, fn)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// SaveDeviceAuthorization stores new request, expired ones are removed on the way so their user codes can be reused.
+// Code taken by a pending request is entity.ErrAlreadyExists.
+func (s *PostgresStorage) SaveDeviceAuthorization(ctx context.Context, d entity.DeviceAuthorization) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM device_authorizations WHERE expires_at <= $1`, time.Now().UTC()); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO device_authorizations(device_code_hash, user_code, client_id, scope, status, interval_seconds, expires_at)
+		VALUES($1,$2,$3,$4,$5,$6,$7)`,
+		d.DeviceCodeHash, d.UserCode, d.ClientID, d.Scope, entity.DeviceStatusPending, int(d.Interval.Seconds()), d.ExpiresAt.UTC())
+	if isUniqueViolation(err) {
+		return entity.ErrAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindDeviceAuthorization returns request waiting for the user decision, decided and expired ones are entity.ErrNotFound.
+func (s *PostgresStorage) FindDeviceAuthorization(ctx context.Context, userCode string) (entity.DeviceAuthorization, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+deviceColumns+` FROM device_authorizations
+		WHERE user_code = $1 AND status = $2 AND expires_at > $3`,
+		userCode, entity.DeviceStatusPending, time.Now().UTC())
+	return scanDeviceAuthorization(row)
+}
+
+// DecideDeviceAuthorization saves approval or denial of a pending request.
+func (s *PostgresStorage) DecideDeviceAuthorization(ctx context.Context, userCode, status, username, tenant string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET status = $1, username = $2, tenant = $3
+		WHERE user_code = $4 AND status = $5 AND expires_at > $6`,
+		status, username, tenant, userCode, entity.DeviceStatusPending, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// PollDeviceAuthorization remembers the poll time and returns the request as it was before, including the previous poll time.
+func (s *PostgresStorage) PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (entity.DeviceAuthorization, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	defer tx.Rollback()
+
+	// concurrent polls of the same device wait for each other, so the second one sees the first one's time
+	d, err := scanDeviceAuthorization(tx.QueryRowContext(ctx, `SELECT `+deviceColumns+` FROM device_authorizations
+		WHERE device_code_hash = $1 FOR UPDATE`, deviceCodeHash))
+	if err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE device_authorizations SET last_polled_at = $1 WHERE device_code_hash = $2`, now.UTC(), deviceCodeHash); err != nil {
+		return entity.DeviceAuthorization{}, err
+	}
+	return d, tx.Commit()
+}
+
+// SlowDownDevicePolling sets new minimum interval between polls.
+func (s *PostgresStorage) SlowDownDevicePolling(ctx context.Context, deviceCodeHash string, interval time.Duration) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET interval_seconds = $1 WHERE device_code_hash = $2`,
+		int(interval.Seconds()), deviceCodeHash)
+	return err
+}
+
+// ConsumeDeviceAuthorization marks approved request as used, so only one token is issued per device code.
+// Requests that are not approved or already consumed are reported as entity.ErrNotFound.
+func (s *PostgresStorage) ConsumeDeviceAuthorization(ctx context.Context, deviceCodeHash string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE device_authorizations SET status = $1 WHERE device_code_hash = $2 AND status = $3`,
+		entity.DeviceStatusConsumed, deviceCodeHash, entity.DeviceStatusApproved)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+func (s *PostgresStorage) SaveMagicLink(ctx context.Context, l entity.MagicLink) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO magic_links(id, username, expires_at) VALUES($1,$2,$3)`,
+		l.ID, l.Username, l.ExpiresAt.UTC())
+	return err
+}
+
+// ConsumeMagicLink marks link as used. Already used or expired links are reported as entity.ErrNotFound.
+func (s *PostgresStorage) ConsumeMagicLink(ctx context.Context, id string) (entity.MagicLink, error) {
+	now := time.Now().UTC()
+
+	l := entity.MagicLink{ID: id, UsedAt: &now}
+	err := s.db.QueryRowContext(ctx, `UPDATE magic_links SET used_at = $1 WHERE id = $2 AND used_at IS NULL AND expires_at > $1
+	RETURNING username, expires_at`, now, id).
+		Scan(&l.Username, &l.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.MagicLink{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.MagicLink{}, err
+	}
+	return l, nil
+}
+
+// MarkUserVerified records that the user owns the address and, the first time only, writes user.verified
+// to the outbox in the same transaction. Reports whether the user has just become verified.
+func (s *PostgresStorage) MarkUserVerified(ctx context.Context, username string, at time.Time) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE users SET verified_at = $1 WHERE username = $2 AND verified_at IS NULL`, at.UTC(), username)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	if err := insertPostgresOutboxEvent(ctx, tx, entity.EventUserVerified, entity.UserEventData{Username: username}); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// insertPostgresOutboxEvent records event inside tx, so it's committed or rolled back together with the change.
+func insertPostgresOutboxEvent(ctx context.Context, tx *sql.Tx, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO outbox_events(type, payload, created_at) VALUES($1,$2,$3)`,
+		eventType, payload, time.Now().UTC())
+	return err
+}
+
+// PendingOutboxEvents claims not yet published events and returns them in the order they were written.
+// Events are published by one replica at a time so they aren't reordered: while another replica holds
+// live claims, nothing is returned. Claims are taken under an advisory lock, so replicas can't race for them.
+func (s *PostgresStorage) PendingOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(`+outboxLockKey+`)`); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	rows, err := tx.QueryContext(ctx, `
+	UPDATE outbox_events SET claimed_by = $1, claimed_until = $2
+	WHERE id IN (SELECT id FROM outbox_events WHERE published_at IS NULL ORDER BY id LIMIT $3)
+	AND NOT EXISTS (
+		SELECT 1 FROM outbox_events
+		WHERE published_at IS NULL AND claimed_by <> $1 AND claimed_until > $4)
+	RETURNING id, type, payload, created_at`, s.owner, now.Add(claimLease), limit, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []entity.OutboxEvent
+	for rows.Next() {
+		var e entity.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.Payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (s *PostgresStorage) MarkOutboxEventPublished(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE outbox_events SET published_at = $1 WHERE id = $2`, at.UTC(), id)
+	return err
+}
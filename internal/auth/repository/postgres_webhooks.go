@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+func (s *PostgresStorage) CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (entity.WebhookSubscription, error) {
+	sub.CreatedAt = time.Now().UTC()
+	err := s.db.QueryRowContext(ctx, `INSERT INTO webhook_subscriptions(url, secret, events, created_at) VALUES($1,$2,$3,$4) RETURNING id`,
+		sub.URL, sub.Secret, strings.Join(sub.Events, ","), sub.CreatedAt).Scan(&sub.ID)
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+	return sub, nil
+}
+
+func (s *PostgresStorage) ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, url, secret, events, created_at FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []entity.WebhookSubscription
+	for rows.Next() {
+		var sub entity.WebhookSubscription
+		var events string
+		if err := rows.Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		sub.Events = strings.Split(events, ",")
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *PostgresStorage) FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+	var events string
+	err := s.db.QueryRowContext(ctx, `SELECT id, url, secret, events, created_at FROM webhook_subscriptions WHERE id = $1`, id).
+		Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookSubscription{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+	sub.Events = strings.Split(events, ",")
+	return sub, nil
+}
+
+// DeleteWebhookSubscription removes subscription together with its delivery log.
+func (s *PostgresStorage) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE subscription_id = $1`, id); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+func (s *PostgresStorage) CreateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) (entity.WebhookDelivery, error) {
+	d.CreatedAt = time.Now().UTC()
+	err := s.db.QueryRowContext(ctx, `
+	INSERT INTO webhook_deliveries(subscription_id, event, payload, status, attempts, next_attempt_at, created_at)
+	VALUES($1,$2,$3,$4,$5,$6,$7) RETURNING id`,
+		d.SubscriptionID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttemptAt.UTC(), d.CreatedAt).Scan(&d.ID)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+// UpdateWebhookDelivery saves outcome of a delivery attempt.
+func (s *PostgresStorage) UpdateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) error {
+	var deliveredAt interface{}
+	if d.DeliveredAt != nil {
+		deliveredAt = d.DeliveredAt.UTC()
+	}
+	_, err := s.db.ExecContext(ctx, `
+	UPDATE webhook_deliveries
+	SET status = $1, attempts = $2, response_code = $3, error = $4, next_attempt_at = $5, delivered_at = $6
+	WHERE id = $7`,
+		d.Status, d.Attempts, d.ResponseCode, d.Error, d.NextAttemptAt.UTC(), deliveredAt, d.ID)
+	return err
+}
+
+func (s *PostgresStorage) FindWebhookDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id)
+	d, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookDelivery{}, entity.ErrNotFound
+	}
+	return d, err
+}
+
+// ListWebhookDeliveries returns delivery log of the subscription, newest first.
+func (s *PostgresStorage) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error) {
+	return queryDeliveries(ctx, s.db, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+	WHERE subscription_id = $1 ORDER BY id DESC LIMIT $2`, subscriptionID, limit)
+}
+
+// DueWebhookDeliveries claims pending deliveries whose next attempt time has come: their next attempt is
+// moved by lease, so other replicas skip them until UpdateWebhookDelivery saves the outcome.
+func (s *PostgresStorage) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	due, err := queryDeliveries(ctx, s.db, `
+	UPDATE webhook_deliveries SET next_attempt_at = $1
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = $2 AND next_attempt_at <= $3
+		ORDER BY next_attempt_at, id LIMIT $4
+		FOR UPDATE SKIP LOCKED)
+	RETURNING `+deliveryColumns, now.Add(lease).UTC(), entity.DeliveryPending, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	return due, nil
+}
@@ -0,0 +1,591 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// то же, что по умолчанию в конфиге
+var sqliteOptions = SQLiteOptions{
+	JournalMode: "wal",
+	BusyTimeout: 5 * time.Second,
+	ForeignKeys: true,
+	Pool:        Pool{MaxOpenConns: 20, MaxIdleConns: 10},
+}
+
+func TestSQLiteStorage(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "db.sql"), sqliteOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	require.NoError(t, s.Migrator().Up(context.Background(), nil))
+
+	testStorage(t, &s)
+}
+
+func TestSQLiteMigrator(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "db.sql"), sqliteOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	testMigrator(t, &s)
+}
+
+// База, созданная до появления миграций, без колонок, добавленных позже, доводится до первой версии
+func TestSQLiteMigratorAdoptsLegacySchema(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "db.sql"), sqliteOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	_, err = s.db.Exec(`
+	CREATE TABLE users (id INTEGER PRIMARY KEY, username text not null, password text not null, created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
+	INSERT INTO users(username, password) VALUES('legacy@example.com', 'hash');`)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Migrator().Up(ctx, nil))
+
+	u, err := s.FindUserByEmail(ctx, "legacy@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleUser, u.Role)
+	assert.False(t, u.PasswordChangedAt.IsZero())
+}
+
+// Настройки применяются к каждому соединению пула, а не только к первому
+func TestSQLiteOptions(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(filepath.Join(t.TempDir(), "db.sql"), sqliteOptions)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	for i := 0; i < 2; i++ {
+		conn, err := s.db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var (
+			journalMode        string
+			busyTimeout, fkeys int
+		)
+		require.NoError(t, conn.QueryRowContext(ctx, `PRAGMA journal_mode`).Scan(&journalMode))
+		require.NoError(t, conn.QueryRowContext(ctx, `PRAGMA busy_timeout`).Scan(&busyTimeout))
+		require.NoError(t, conn.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&fkeys))
+		assert.Equal(t, "wal", journalMode)
+		assert.Equal(t, 5000, busyTimeout)
+		assert.Equal(t, 1, fkeys)
+	}
+}
+
+// Пропускная способность логина (поиск пользователя и отметка о входе) под параллельной нагрузкой:
+// go test ./internal/auth/repository -run '^$' -bench SQLiteLogin -cpu 1,4,16
+func BenchmarkSQLiteLogin(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts SQLiteOptions
+	}{
+		{"rollback_journal", SQLiteOptions{BusyTimeout: sqliteOptions.BusyTimeout, Pool: sqliteOptions.Pool}},
+		{"wal", sqliteOptions},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+			s, err := New(filepath.Join(b.TempDir(), "db.sql"), bc.opts)
+			require.NoError(b, err)
+			b.Cleanup(func() { s.Close() })
+			require.NoError(b, s.Migrator().Up(ctx, nil))
+
+			const users = 100
+			for i := 0; i < users; i++ {
+				_, err := s.RegisterUser(ctx, entity.NewUserAccount(fmt.Sprintf("user%d@example.com", i), "hash"), nil)
+				require.NoError(b, err)
+			}
+
+			var next atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					u, err := s.FindUserByEmail(ctx, fmt.Sprintf("user%d@example.com", next.Add(1)%users))
+					if err == nil {
+						err = s.UpdateLastLogin(ctx, u.Username, time.Now())
+					}
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// Тот же набор на PostgreSQL, нужна отдельная тестовая база - все таблицы в ней очищаются
+func TestPostgresStorage(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	s, err := NewPostgres(dsn, Pool{MaxOpenConns: 4})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	require.NoError(t, s.Migrator().Up(context.Background(), nil))
+
+	_, err = s.db.Exec(`TRUNCATE users, password_history, magic_links, device_authorizations, organizations, memberships,
+		audit_events, audit_checkpoints, webhook_subscriptions, webhook_deliveries, outbox_events RESTART IDENTITY CASCADE`)
+	require.NoError(t, err)
+
+	testStorage(t, &s)
+}
+
+// Реплики на одной базе не отправляют одно и то же: строки захватываются на время аренды
+func TestPostgresClaims(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	ctx := context.Background()
+
+	first, err := NewPostgres(dsn, Pool{MaxOpenConns: 4})
+	require.NoError(t, err)
+	t.Cleanup(func() { first.Close() })
+	second, err := NewPostgres(dsn, Pool{MaxOpenConns: 4})
+	require.NoError(t, err)
+	t.Cleanup(func() { second.Close() })
+	require.NoError(t, first.Migrator().Up(ctx, nil))
+	_, err = first.db.Exec(`TRUNCATE users, memberships, webhook_subscriptions, webhook_deliveries, outbox_events RESTART IDENTITY CASCADE`)
+	require.NoError(t, err)
+
+	for _, username := range []string{"alice@example.com", "bob@example.com"} {
+		_, err := first.RegisterUser(ctx, entity.UserAccount{Username: username, Password: "hash"}, nil)
+		require.NoError(t, err)
+	}
+	events, err := first.PendingOutboxEvents(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	events, err = second.PendingOutboxEvents(ctx, 100)
+	require.NoError(t, err)
+	assert.Empty(t, events, "events are published by one replica at a time to keep the order")
+	events, err = first.PendingOutboxEvents(ctx, 100)
+	require.NoError(t, err)
+	assert.Len(t, events, 2, "the claiming replica keeps publishing")
+
+	// реплика умерла: после claimLease события забирает другая
+	_, err = first.db.Exec(`UPDATE outbox_events SET claimed_until = $1`, time.Now().Add(-time.Second).UTC())
+	require.NoError(t, err)
+	events, err = second.PendingOutboxEvents(ctx, 100)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	sub, err := first.CreateWebhookSubscription(ctx, entity.WebhookSubscription{URL: "https://example.com/hook", Secret: "secret", Events: []string{entity.EventUserRegistered}})
+	require.NoError(t, err)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := first.CreateWebhookDelivery(ctx, entity.WebhookDelivery{SubscriptionID: sub.ID, Event: entity.EventUserRegistered, Payload: []byte(`{}`), Status: entity.DeliveryPending, NextAttemptAt: now.Add(-time.Second)})
+		require.NoError(t, err)
+	}
+	lease := 10 * time.Second
+	due, err := first.DueWebhookDeliveries(ctx, now, 2, lease)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	rest, err := second.DueWebhookDeliveries(ctx, now, 10, lease)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.NotContains(t, []int64{due[0].ID, due[1].ID}, rest[0].ID)
+	rest, err = second.DueWebhookDeliveries(ctx, now.Add(lease+time.Second), 10, lease)
+	require.NoError(t, err)
+	assert.Len(t, rest, 3, "unfinished deliveries are due again after the lease")
+}
+
+func TestPostgresMigrator(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	s, err := NewPostgres(dsn, Pool{MaxOpenConns: 4})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	// начинаем с пустой базы
+	require.NoError(t, s.Migrator().Goto(context.Background(), 0, nil))
+
+	testMigrator(t, &s)
+}
+
+// testMigrator проходит все миграции вверх, вниз и снова вверх
+func testMigrator(t *testing.T, s Storage) {
+	ctx := context.Background()
+	m := s.Migrator()
+
+	migrations, err := m.Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	latest := migrations[len(migrations)-1].Version
+
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.Error(t, m.Check(ctx))
+
+	var applied []int
+	require.NoError(t, m.Up(ctx, func(mig Migration) { applied = append(applied, mig.Version) }))
+	assert.Len(t, applied, len(migrations))
+	require.NoError(t, m.Check(ctx))
+	_, err = s.RegisterUser(ctx, entity.UserAccount{Username: "alice@example.com", Password: "hash"}, nil)
+	require.NoError(t, err)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	for _, st := range statuses {
+		assert.NotNil(t, st.AppliedAt, st.Name)
+	}
+
+	// повторный up ничего не делает
+	require.NoError(t, m.Up(ctx, func(mig Migration) { t.Errorf("%d applied twice", mig.Version) }))
+
+	require.NoError(t, m.Down(ctx, 1, nil))
+	version, err = m.Version(ctx)
+	require.NoError(t, err)
+	if len(migrations) > 1 {
+		assert.Equal(t, migrations[len(migrations)-2].Version, version)
+	} else {
+		assert.Zero(t, version)
+	}
+
+	require.NoError(t, m.Goto(ctx, 0, nil))
+	_, err = s.FindUserByEmail(ctx, "alice@example.com")
+	assert.Error(t, err, "tables are dropped")
+	statuses, err = m.Status(ctx)
+	require.NoError(t, err)
+	for _, st := range statuses {
+		assert.Nil(t, st.AppliedAt, st.Name)
+	}
+
+	require.NoError(t, m.Goto(ctx, latest, nil))
+	require.NoError(t, m.Check(ctx))
+	assert.Error(t, m.Goto(ctx, latest+1, nil))
+}
+
+// testStorage проверяет одинаковое поведение всех реализаций Storage
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	t.Run("Users", func(t *testing.T) {
+		registered, err := s.RegisterUser(ctx, entity.UserAccount{Username: "alice@example.com", Email: "alice@example.com", DisplayName: "Alice", Password: "hash1"}, nil)
+		require.NoError(t, err)
+		assert.NotZero(t, registered.ID)
+		assert.Equal(t, entity.UserStatusActive, registered.Status)
+
+		u, err := s.FindUserByEmail(ctx, "alice@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, registered.ID, u.ID)
+		assert.Equal(t, "alice@example.com", u.Email)
+		assert.Equal(t, "Alice", u.DisplayName)
+		assert.Equal(t, "hash1", u.Password)
+		assert.Equal(t, entity.RoleUser, u.Role)
+		assert.Equal(t, entity.UserStatusActive, u.Status)
+		assert.WithinDuration(t, time.Now(), u.PasswordChangedAt, time.Minute)
+		assert.True(t, registered.CreatedAt.Equal(u.CreatedAt))
+		assert.True(t, u.CreatedAt.Equal(u.UpdatedAt))
+		assert.Nil(t, u.LastLoginAt)
+
+		other, err := s.RegisterUser(ctx, entity.UserAccount{Username: "dave@example.com", Password: "hash"}, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, registered.ID, other.ID)
+		_, err = s.RegisterUser(ctx, entity.UserAccount{Username: "dave@example.com", Password: "hash"}, nil)
+		assert.ErrorIs(t, err, entity.ErrAlreadyExists)
+
+		// импорт не публикует user.registered, см. проверку outbox ниже
+		imported, err := s.ImportUser(ctx, entity.UserAccount{Username: "frank@example.com", Password: "$2a$10$hash"})
+		require.NoError(t, err)
+		assert.NotZero(t, imported.ID)
+		_, err = s.ImportUser(ctx, entity.UserAccount{Username: "frank@example.com", Password: "hash"})
+		assert.ErrorIs(t, err, entity.ErrAlreadyExists)
+
+		loginAt := time.Now()
+		require.NoError(t, s.UpdateLastLogin(ctx, "alice@example.com", loginAt))
+		require.NoError(t, s.SetUserStatus(ctx, "alice@example.com", entity.UserStatusDisabled))
+		u, err = s.FindUserByEmail(ctx, "alice@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, u.LastLoginAt)
+		assert.WithinDuration(t, loginAt, *u.LastLoginAt, time.Millisecond)
+		assert.Equal(t, entity.UserStatusDisabled, u.Status)
+		assert.True(t, u.UpdatedAt.After(u.CreatedAt))
+		assert.ErrorIs(t, s.SetUserStatus(ctx, "nobody@example.com", entity.UserStatusDisabled), entity.ErrNotFound)
+
+		require.NoError(t, s.SetUserRole(ctx, "alice@example.com", entity.RoleAdmin))
+		require.NoError(t, s.UpdatePassword(ctx, "alice@example.com", "hash2"))
+		u, err = s.FindUserByEmail(ctx, "alice@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, entity.RoleAdmin, u.Role)
+		assert.Equal(t, "hash2", u.Password)
+
+		_, err = s.FindUserByEmail(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.ErrorIs(t, s.SetUserRole(ctx, "nobody@example.com", entity.RoleAdmin), entity.ErrNotFound)
+		assert.ErrorIs(t, s.UpdatePassword(ctx, "nobody@example.com", "hash"), entity.ErrNotFound)
+
+		require.NoError(t, s.DeleteUser(ctx, "alice@example.com"))
+		_, err = s.FindUserByEmail(ctx, "alice@example.com")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.ErrorIs(t, s.DeleteUser(ctx, "alice@example.com"), entity.ErrNotFound)
+	})
+
+	t.Run("Password history", func(t *testing.T) {
+		_, err := s.RegisterUser(ctx, entity.UserAccount{Username: "bob@example.com", Password: "hash1"}, nil)
+		require.NoError(t, err)
+		for _, hash := range []string{"hash2", "hash3", "hash4"} {
+			require.NoError(t, s.ChangePassword(ctx, "bob@example.com", hash, 2))
+		}
+
+		history, err := s.PasswordHistory(ctx, "bob@example.com", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hash3", "hash2"}, history)
+
+		u, err := s.FindUserByEmail(ctx, "bob@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "hash4", u.Password)
+
+		assert.ErrorIs(t, s.ChangePassword(ctx, "nobody@example.com", "hash", 2), entity.ErrNotFound)
+	})
+
+	t.Run("Magic links", func(t *testing.T) {
+		require.NoError(t, s.SaveMagicLink(ctx, entity.MagicLink{ID: "link1", Username: "bob@example.com", ExpiresAt: time.Now().Add(time.Hour)}))
+		require.NoError(t, s.SaveMagicLink(ctx, entity.MagicLink{ID: "expired", Username: "bob@example.com", ExpiresAt: time.Now().Add(-time.Minute)}))
+
+		l, err := s.ConsumeMagicLink(ctx, "link1")
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", l.Username)
+		require.NotNil(t, l.UsedAt)
+
+		_, err = s.ConsumeMagicLink(ctx, "link1")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		_, err = s.ConsumeMagicLink(ctx, "expired")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+
+		// user.verified пишется в outbox только при первом подтверждении
+		verified, err := s.MarkUserVerified(ctx, "bob@example.com", time.Now())
+		require.NoError(t, err)
+		assert.True(t, verified)
+		verified, err = s.MarkUserVerified(ctx, "bob@example.com", time.Now())
+		require.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("Device authorization", func(t *testing.T) {
+		require.NoError(t, s.SaveDeviceAuthorization(ctx, entity.DeviceAuthorization{
+			DeviceCodeHash: "device-hash",
+			UserCode:       "BCDFGHJK",
+			ClientID:       "tv",
+			Scope:          "profile",
+			Interval:       5 * time.Second,
+			ExpiresAt:      time.Now().Add(10 * time.Minute),
+		}))
+
+		err := s.SaveDeviceAuthorization(ctx, entity.DeviceAuthorization{
+			DeviceCodeHash: "other-device-hash",
+			UserCode:       "BCDFGHJK",
+			ClientID:       "cli",
+			Interval:       5 * time.Second,
+			ExpiresAt:      time.Now().Add(10 * time.Minute),
+		})
+		assert.ErrorIs(t, err, entity.ErrAlreadyExists, "user code of a pending request")
+
+		d, err := s.FindDeviceAuthorization(ctx, "BCDFGHJK")
+		require.NoError(t, err)
+		assert.Equal(t, "tv", d.ClientID)
+		assert.Equal(t, entity.DeviceStatusPending, d.Status)
+		assert.Equal(t, 5*time.Second, d.Interval)
+
+		polledAt := time.Now()
+		d, err = s.PollDeviceAuthorization(ctx, "device-hash", polledAt)
+		require.NoError(t, err)
+		assert.Nil(t, d.LastPolledAt)
+		d, err = s.PollDeviceAuthorization(ctx, "device-hash", polledAt.Add(time.Second))
+		require.NoError(t, err)
+		require.NotNil(t, d.LastPolledAt)
+		assert.WithinDuration(t, polledAt, *d.LastPolledAt, time.Millisecond)
+
+		require.NoError(t, s.SlowDownDevicePolling(ctx, "device-hash", 10*time.Second))
+		assert.ErrorIs(t, s.ConsumeDeviceAuthorization(ctx, "device-hash"), entity.ErrNotFound, "not approved yet")
+
+		require.NoError(t, s.DecideDeviceAuthorization(ctx, "BCDFGHJK", entity.DeviceStatusApproved, "bob@example.com", "acme"))
+		_, err = s.FindDeviceAuthorization(ctx, "BCDFGHJK")
+		assert.ErrorIs(t, err, entity.ErrNotFound, "only pending requests are shown")
+		assert.ErrorIs(t, s.DecideDeviceAuthorization(ctx, "BCDFGHJK", entity.DeviceStatusDenied, "bob@example.com", ""), entity.ErrNotFound)
+
+		require.NoError(t, s.ConsumeDeviceAuthorization(ctx, "device-hash"))
+		assert.ErrorIs(t, s.ConsumeDeviceAuthorization(ctx, "device-hash"), entity.ErrNotFound)
+
+		d, err = s.PollDeviceAuthorization(ctx, "device-hash", time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, entity.DeviceStatusConsumed, d.Status)
+		assert.Equal(t, "bob@example.com", d.Username)
+		assert.Equal(t, "acme", d.Tenant)
+		assert.Equal(t, 10*time.Second, d.Interval)
+
+		_, err = s.PollDeviceAuthorization(ctx, "unknown", time.Now())
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+
+	t.Run("Organizations", func(t *testing.T) {
+		org, err := s.CreateOrganization(ctx, entity.Organization{Slug: "acme", Name: "Acme", Settings: entity.OrganizationSettings{RegistrationPolicy: entity.RegistrationClosed}})
+		require.NoError(t, err)
+		assert.NotZero(t, org.ID)
+
+		_, err = s.CreateOrganization(ctx, entity.Organization{Slug: "acme", Name: "Acme 2"})
+		assert.ErrorIs(t, err, entity.ErrAlreadyExists)
+
+		require.NoError(t, s.UpdateOrganizationSettings(ctx, org.ID, entity.OrganizationSettings{RegistrationPolicy: entity.RegistrationOpen}))
+		found, err := s.FindOrganization(ctx, "acme")
+		require.NoError(t, err)
+		assert.Equal(t, org.ID, found.ID)
+		assert.Equal(t, entity.RegistrationOpen, found.Settings.RegistrationPolicy)
+		_, err = s.FindOrganization(ctx, "unknown")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+
+		require.NoError(t, s.SaveMembership(ctx, entity.Membership{OrgID: org.ID, Username: "bob@example.com", Role: entity.OrgRoleMember}))
+		require.NoError(t, s.SaveMembership(ctx, entity.Membership{OrgID: org.ID, Username: "bob@example.com", Role: entity.OrgRoleAdmin}))
+		require.NoError(t, s.SaveMembership(ctx, entity.Membership{OrgID: org.ID, Username: "carol@example.com", Role: entity.OrgRoleMember}))
+
+		m, err := s.FindMembership(ctx, org.ID, "bob@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrgRoleAdmin, m.Role)
+		_, err = s.FindMembership(ctx, org.ID, "dave@example.com")
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+
+		// регистрация в организации сразу добавляет участника
+		_, err = s.RegisterUser(ctx, entity.UserAccount{Username: "erin@example.com", Password: "hash"}, &org)
+		require.NoError(t, err)
+
+		members, err := s.ListMembers(ctx, org.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 3)
+		assert.Equal(t, "bob@example.com", members[0].Username)
+		assert.Equal(t, "carol@example.com", members[1].Username)
+		assert.Equal(t, entity.Membership{OrgID: org.ID, Username: "erin@example.com", Role: entity.OrgRoleMember}, members[2])
+	})
+
+	t.Run("Audit log", func(t *testing.T) {
+		_, err := s.LastAuditEvent(ctx)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+
+		start := time.Now()
+		for _, actor := range []string{"bob@example.com", "carol@example.com", "bob@example.com"} {
+			require.NoError(t, s.AppendAuditEvent(ctx, entity.AuditEvent{
+				OccurredAt: time.Now(),
+				Actor:      actor,
+				Action:     entity.AuditLogin,
+				Result:     entity.AuditResultSuccess,
+			}))
+		}
+
+		var events []entity.AuditEvent
+		require.NoError(t, s.ExportAuditEvents(ctx, entity.AuditFilter{}, func(e entity.AuditEvent) error {
+			events = append(events, e)
+			return nil
+		}))
+		require.Len(t, events, 3)
+		prev := ""
+		for _, e := range events {
+			// хеш пересчитывается по прочитанной записи, значит время сохраняется без потерь
+			assert.Equal(t, prev, e.PrevHash)
+			assert.Equal(t, e.ChainHash(), e.Hash)
+			prev = e.Hash
+		}
+
+		last, err := s.LastAuditEvent(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, events[2].ID, last.ID)
+
+		listed, err := s.ListAuditEvents(ctx, entity.AuditFilter{Actor: "bob@example.com", Since: start.Add(-time.Second), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, events[2].ID, listed[0].ID)
+
+		_, err = s.LastAuditCheckpoint(ctx)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		require.NoError(t, s.SaveAuditCheckpoint(ctx, entity.AuditCheckpoint{EventID: last.ID, Hash: last.Hash, Signature: []byte{1, 2, 3}, CreatedAt: time.Now()}))
+		c, err := s.LastAuditCheckpoint(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, last.Hash, c.Hash)
+		assert.Equal(t, []byte{1, 2, 3}, c.Signature)
+
+		var checkpoints int
+		require.NoError(t, s.ExportAuditCheckpoints(ctx, func(entity.AuditCheckpoint) error {
+			checkpoints++
+			return nil
+		}))
+		assert.Equal(t, 1, checkpoints)
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
+		sub, err := s.CreateWebhookSubscription(ctx, entity.WebhookSubscription{URL: "https://example.com/hook", Secret: "secret", Events: []string{entity.EventUserRegistered, entity.EventUserDeleted}})
+		require.NoError(t, err)
+		assert.NotZero(t, sub.ID)
+
+		found, err := s.FindWebhookSubscription(ctx, sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sub.Events, found.Events)
+		subs, err := s.ListWebhookSubscriptions(ctx)
+		require.NoError(t, err)
+		assert.Len(t, subs, 1)
+
+		now := time.Now()
+		d, err := s.CreateWebhookDelivery(ctx, entity.WebhookDelivery{SubscriptionID: sub.ID, Event: entity.EventUserRegistered, Payload: []byte(`{}`), Status: entity.DeliveryPending, NextAttemptAt: now.Add(-time.Second)})
+		require.NoError(t, err)
+		_, err = s.CreateWebhookDelivery(ctx, entity.WebhookDelivery{SubscriptionID: sub.ID, Event: entity.EventUserDeleted, Payload: []byte(`{}`), Status: entity.DeliveryPending, NextAttemptAt: now.Add(time.Hour)})
+		require.NoError(t, err)
+
+		due, err := s.DueWebhookDeliveries(ctx, now, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, d.ID, due[0].ID)
+
+		d.Status, d.Attempts, d.ResponseCode, d.DeliveredAt = entity.DeliveryDelivered, 1, 200, &now
+		require.NoError(t, s.UpdateWebhookDelivery(ctx, d))
+		d, err = s.FindWebhookDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.DeliveryDelivered, d.Status)
+		assert.Equal(t, 200, d.ResponseCode)
+		require.NotNil(t, d.DeliveredAt)
+
+		deliveries, err := s.ListWebhookDeliveries(ctx, sub.ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+
+		require.NoError(t, s.DeleteWebhookSubscription(ctx, sub.ID))
+		_, err = s.FindWebhookSubscription(ctx, sub.ID)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		_, err = s.FindWebhookDelivery(ctx, d.ID)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		assert.ErrorIs(t, s.DeleteWebhookSubscription(ctx, sub.ID), entity.ErrNotFound)
+	})
+
+	// изменения пользователей выше попадают в outbox в той же транзакции
+	t.Run("Outbox", func(t *testing.T) {
+		events, err := s.PendingOutboxEvents(ctx, 100)
+		require.NoError(t, err)
+		var types []string
+		for _, e := range events {
+			types = append(types, e.Type)
+		}
+		assert.Equal(t, []string{
+			entity.EventUserRegistered, entity.EventUserRegistered, entity.EventUserDeleted,
+			entity.EventUserRegistered, entity.EventUserPasswordChanged, entity.EventUserPasswordChanged, entity.EventUserPasswordChanged,
+			entity.EventUserVerified, entity.EventUserRegistered,
+		}, types)
+		assert.JSONEq(t, `{"username":"erin@example.com","organization":"acme"}`, string(events[len(events)-1].Payload))
+
+		require.NoError(t, s.MarkOutboxEventPublished(ctx, events[0].ID, time.Now()))
+		events, err = s.PendingOutboxEvents(ctx, 100)
+		require.NoError(t, err)
+		assert.Len(t, events, 8)
+	})
+}
@@ -3,71 +3,325 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/bogatyr285/auth-go/internal/auth/entity"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
 type SQLLiteStorage struct {
 	db *sql.DB
+	// serializes audit appends, each one reads the previous hash
+	auditMu *sync.Mutex
+	// queries of the hot paths, closed by Close
+	stmts *statements
 }
 
-func New(dbPath string) (SQLLiteStorage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
-	if err != nil {
-		return SQLLiteStorage{}, err
+// SQLiteOptions - settings of every connection of the pool, zero values keep sqlite defaults.
+type SQLiteOptions struct {
+	// wal lets readers work while a write is in progress
+	JournalMode string
+	// how long a connection waits for the lock instead of failing with "database is locked"
+	BusyTimeout time.Duration
+	ForeignKeys bool
+	Pool        Pool
+}
+
+// dsn passes the options as go-sqlite3 parameters, a PRAGMA run once would reach only one connection.
+func (o SQLiteOptions) dsn(path string) string {
+	params := url.Values{}
+	if o.JournalMode != "" {
+		params.Set("_journal_mode", o.JournalMode)
 	}
-	stmt, err := db.Prepare(`
-	CREATE TABLE IF NOT EXISTS users (
-		id INTEGER PRIMARY KEY,
-		username text not null,
-		password text not null,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP);
-	create index if not exists idx_username ON users(username);
-	`)
-	if err != nil {
-		return SQLLiteStorage{}, fmt.Errorf("db schema init err: %s", err)
+	if o.BusyTimeout > 0 {
+		params.Set("_busy_timeout", strconv.FormatInt(o.BusyTimeout.Milliseconds(), 10))
+	}
+	if o.ForeignKeys {
+		params.Set("_foreign_keys", "1")
 	}
+	// every transaction here writes; taking the write lock at BEGIN makes it wait for busy_timeout,
+	// a deferred one upgrading its read lock gets SQLITE_BUSY at once
+	params.Set("_txlock", "immediate")
 
-	_, err = stmt.Exec()
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + params.Encode()
+}
+
+func New(dbPath string, opts SQLiteOptions) (SQLLiteStorage, error) {
+	db, err := sql.Open("sqlite3", opts.dsn(dbPath))
 	if err != nil {
 		return SQLLiteStorage{}, err
 	}
-	return SQLLiteStorage{db: db}, nil
+	opts.Pool.apply(db)
+
+	return SQLLiteStorage{db: db, auditMu: &sync.Mutex{}, stmts: newStatements(db)}, nil
 }
 
-func (s *SQLLiteStorage) Close() error {
-	return s.db.Close()
+// Migrator manages the schema, New doesn't touch it.
+func (s *SQLLiteStorage) Migrator() *Migrator {
+	return &Migrator{
+		db:   s.db,
+		dir:  "migrations/sqlite",
+		bind: func(int) string { return "?" },
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name text not null,
+			applied_at TIMESTAMP not null)`,
+		tableExists: `SELECT count(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`,
+		after:       map[int]func(context.Context, *sql.Tx) error{1: adoptLegacySchema},
+	}
+}
+
+// adoptLegacySchema brings databases created before migrations existed to the state of the first one.
+func adoptLegacySchema(ctx context.Context, tx *sql.Tx) error {
+	for _, c := range []struct{ table, column, definition string }{
+		{"users", "role", `text not null default 'user'`},
+		{"users", "password_changed_at", `TIMESTAMP`},
+		{"audit_events", "prev_hash", `text not null default ''`},
+		{"audit_events", "hash", `text not null default ''`},
+	} {
+		if err := addColumnIfMissing(ctx, tx, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+
+	// rotation period of existing accounts starts from registration
+	_, err := tx.ExecContext(ctx, `UPDATE users SET password_changed_at = created_at WHERE password_changed_at IS NULL`)
+	return err
 }
 
-func (s *SQLLiteStorage) RegisterUser(ctx context.Context, u entity.UserAccount) error {
-	stmt, err := s.db.PrepareContext(ctx, `INSERT INTO users(username, password) VALUES(?,?)`)
+func addColumnIfMissing(ctx context.Context, tx *sql.Tx, table, column, definition string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	if _, err := stmt.Exec(u.Username, u.Password); err != nil {
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
 		return err
 	}
+	rows.Close()
 
-	return nil
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+	return err
+}
+
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+func (s *SQLLiteStorage) Close() error {
+	return errors.Join(s.stmts.close(), s.db.Close())
+}
+
+// RegisterUser stores new account and returns it with the assigned id and timestamps.
+// If org is set the user joins it as a member in the same transaction. A taken username is ErrAlreadyExists.
+func (s *SQLLiteStorage) RegisterUser(ctx context.Context, u entity.UserAccount, org *entity.Organization) (entity.UserAccount, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.UserAccount{}, err
+	}
+	defer tx.Rollback()
+
+	if u, err = s.insertUser(ctx, tx, u); err != nil {
+		return entity.UserAccount{}, err
+	}
+
+	event := entity.UserEventData{Username: u.Username}
+	if org != nil {
+		_, err := tx.ExecContext(ctx, `INSERT INTO memberships(org_id, username, role) VALUES(?,?,?)`, org.ID, u.Username, entity.OrgRoleMember)
+		if err != nil {
+			return entity.UserAccount{}, err
+		}
+		event.Organization = org.Slug
+	}
+	if err := insertOutboxEvent(ctx, tx, entity.EventUserRegistered, event); err != nil {
+		return entity.UserAccount{}, err
+	}
+
+	return u, tx.Commit()
+}
+
+// ImportUser stores account moved from another system. Unlike RegisterUser it doesn't announce
+// user.registered - the user isn't new, only new to this service.
+func (s *SQLLiteStorage) ImportUser(ctx context.Context, u entity.UserAccount) (entity.UserAccount, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.UserAccount{}, err
+	}
+	defer tx.Rollback()
+
+	if u, err = s.insertUser(ctx, tx, u); err != nil {
+		return entity.UserAccount{}, err
+	}
+	return u, tx.Commit()
+}
+
+func (s *SQLLiteStorage) insertUser(ctx context.Context, tx *sql.Tx, u entity.UserAccount) (entity.UserAccount, error) {
+	if u.Role == "" {
+		u.Role = entity.RoleUser
+	}
+	if u.Status == "" {
+		u.Status = entity.UserStatusActive
+	}
+	now := time.Now().UTC()
+	u.CreatedAt, u.UpdatedAt, u.PasswordChangedAt = now, now, now
+
+	stmt, err := s.stmts.inTx(ctx, tx, `INSERT INTO users(username, email, display_name, password, role, status, password_changed_at, created_at, updated_at)
+	VALUES(?,?,?,?,?,?,?,?,?)`)
+	if err != nil {
+		return entity.UserAccount{}, err
+	}
+
+	res, err := stmt.ExecContext(ctx, u.Username, u.Email, u.DisplayName, u.Password, u.Role, u.Status, now, now, now)
+	if isSQLiteUniqueViolation(err) {
+		return entity.UserAccount{}, entity.ErrAlreadyExists
+	}
+	if err != nil {
+		return entity.UserAccount{}, err
+	}
+	if u.ID, err = res.LastInsertId(); err != nil {
+		return entity.UserAccount{}, err
+	}
+	return u, nil
 }
 
 func (s *SQLLiteStorage) FindUserByEmail(ctx context.Context, username string) (entity.UserAccount, error) {
-	stmt, err := s.db.PrepareContext(ctx, `SELECT password FROM users WHERE username = ?`)
+	stmt, err := s.stmts.get(ctx, `SELECT `+userColumns+` FROM users WHERE username = ?`)
 	if err != nil {
 		return entity.UserAccount{}, err
 	}
 
-	var pswdFromDB string
+	return scanUser(stmt.QueryRowContext(ctx, username))
+}
+
+const userColumns = `id, username, email, display_name, password, role, status, created_at, updated_at, last_login_at, password_changed_at`
 
-	if err := stmt.QueryRow(username).Scan(&pswdFromDB); err != nil {
+func scanUser(row scanner) (entity.UserAccount, error) {
+	var (
+		u                                          entity.UserAccount
+		createdAt, updatedAt, lastLogin, changedAt sql.NullTime
+	)
+	err := row.Scan(&u.ID, &u.Username, &u.Email, &u.DisplayName, &u.Password, &u.Role, &u.Status,
+		&createdAt, &updatedAt, &lastLogin, &changedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.UserAccount{}, entity.ErrNotFound
+	}
+	if err != nil {
 		return entity.UserAccount{}, err
 	}
+	u.CreatedAt, u.UpdatedAt, u.PasswordChangedAt = createdAt.Time, updatedAt.Time, changedAt.Time
+	if lastLogin.Valid {
+		u.LastLoginAt = &lastLogin.Time
+	}
+	return u, nil
+}
+
+// DeleteUser removes the account and its organization memberships.
+func (s *SQLLiteStorage) DeleteUser(ctx context.Context, username string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM memberships WHERE username = ?`, username); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM password_history WHERE username = ?`, username); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE username = ?`, username)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	if err := insertOutboxEvent(ctx, tx, entity.EventUserDeleted, entity.UserEventData{Username: username}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLLiteStorage) UpdatePassword(ctx context.Context, username, hash string) error {
+	stmt, err := s.stmts.get(ctx, `UPDATE users SET password = ?, updated_at = ? WHERE username = ?`)
+	if err != nil {
+		return err
+	}
+	res, err := stmt.ExecContext(ctx, hash, time.Now().UTC(), username)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLLiteStorage) SetUserRole(ctx context.Context, username, role string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE users SET role = ?, updated_at = ? WHERE username = ?`, role, time.Now().UTC(), username)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
 
-	return entity.UserAccount{
-		Username: username,
-		Password: pswdFromDB,
-	}, nil
+func (s *SQLLiteStorage) SetUserStatus(ctx context.Context, username, status string) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE users SET status = ?, updated_at = ? WHERE username = ?`, status, time.Now().UTC(), username)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateLastLogin remembers successful login, it isn't a change of the account so updated_at stays.
+func (s *SQLLiteStorage) UpdateLastLogin(ctx context.Context, username string, at time.Time) error {
+	stmt, err := s.stmts.get(ctx, `UPDATE users SET last_login_at = ? WHERE username = ?`)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, at.UTC(), username)
+	return err
 }
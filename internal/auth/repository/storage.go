@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// Storage - everything the service keeps in the database. SQLLiteStorage suits a single instance,
+// PostgresStorage is shared by any number of replicas.
+type Storage interface {
+	RegisterUser(ctx context.Context, u entity.UserAccount, org *entity.Organization) (entity.UserAccount, error)
+	ImportUser(ctx context.Context, u entity.UserAccount) (entity.UserAccount, error)
+	FindUserByEmail(ctx context.Context, username string) (entity.UserAccount, error)
+	DeleteUser(ctx context.Context, username string) error
+	UpdatePassword(ctx context.Context, username, hash string) error
+	SetUserRole(ctx context.Context, username, role string) error
+	SetUserStatus(ctx context.Context, username, status string) error
+	UpdateLastLogin(ctx context.Context, username string, at time.Time) error
+	ChangePassword(ctx context.Context, username, hash string, keep int) error
+	PasswordHistory(ctx context.Context, username string, limit int) ([]string, error)
+
+	SaveMagicLink(ctx context.Context, l entity.MagicLink) error
+	ConsumeMagicLink(ctx context.Context, id string) (entity.MagicLink, error)
+	MarkUserVerified(ctx context.Context, username string, at time.Time) (bool, error)
+
+	SaveDeviceAuthorization(ctx context.Context, d entity.DeviceAuthorization) error
+	FindDeviceAuthorization(ctx context.Context, userCode string) (entity.DeviceAuthorization, error)
+	DecideDeviceAuthorization(ctx context.Context, userCode, status, username, tenant string) error
+	PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (entity.DeviceAuthorization, error)
+	SlowDownDevicePolling(ctx context.Context, deviceCodeHash string, interval time.Duration) error
+	ConsumeDeviceAuthorization(ctx context.Context, deviceCodeHash string) error
+
+	CreateOrganization(ctx context.Context, o entity.Organization) (entity.Organization, error)
+	FindOrganization(ctx context.Context, slug string) (entity.Organization, error)
+	UpdateOrganizationSettings(ctx context.Context, orgID int64, settings entity.OrganizationSettings) error
+	SaveMembership(ctx context.Context, m entity.Membership) error
+	FindMembership(ctx context.Context, orgID int64, username string) (entity.Membership, error)
+	ListMembers(ctx context.Context, orgID int64) ([]entity.Membership, error)
+
+	AppendAuditEvent(ctx context.Context, e entity.AuditEvent) error
+	LastAuditEvent(ctx context.Context) (entity.AuditEvent, error)
+	ListAuditEvents(ctx context.Context, f entity.AuditFilter) ([]entity.AuditEvent, error)
+	ExportAuditEvents(ctx context.Context, f entity.AuditFilter, fn func(entity.AuditEvent) error) error
+	SaveAuditCheckpoint(ctx context.Context, c entity.AuditCheckpoint) error
+	LastAuditCheckpoint(ctx context.Context) (entity.AuditCheckpoint, error)
+	ExportAuditCheckpoints(ctx context.Context, fn func(entity.AuditCheckpoint) error) error
+
+	CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (entity.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	CreateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) (entity.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) error
+	FindWebhookDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error)
+	DueWebhookDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+
+	PendingOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64, at time.Time) error
+
+	Migrator() *Migrator
+	Close() error
+}
+
+var (
+	_ Storage = (*SQLLiteStorage)(nil)
+	_ Storage = (*PostgresStorage)(nil)
+)
+
+// Pool - connection pool limits, zero values keep database/sql defaults.
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+func (p Pool) apply(db *sql.DB) {
+	if p.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+	if p.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+func (s *SQLLiteStorage) CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (entity.WebhookSubscription, error) {
+	sub.CreatedAt = time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, `INSERT INTO webhook_subscriptions(url, secret, events, created_at) VALUES(?,?,?,?)`,
+		sub.URL, sub.Secret, strings.Join(sub.Events, ","), sub.CreatedAt)
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+	sub.ID, err = res.LastInsertId()
+	return sub, err
+}
+
+func (s *SQLLiteStorage) ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, url, secret, events, created_at FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []entity.WebhookSubscription
+	for rows.Next() {
+		var sub entity.WebhookSubscription
+		var events string
+		if err := rows.Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		sub.Events = strings.Split(events, ",")
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *SQLLiteStorage) FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+	var events string
+	err := s.db.QueryRowContext(ctx, `SELECT id, url, secret, events, created_at FROM webhook_subscriptions WHERE id = ?`, id).
+		Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookSubscription{}, entity.ErrNotFound
+	}
+	if err != nil {
+		return entity.WebhookSubscription{}, err
+	}
+	sub.Events = strings.Split(events, ",")
+	return sub, nil
+}
+
+// DeleteWebhookSubscription removes subscription together with its delivery log.
+func (s *SQLLiteStorage) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE subscription_id = ?`, id); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entity.ErrNotFound
+	}
+	return tx.Commit()
+}
+
+func (s *SQLLiteStorage) CreateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) (entity.WebhookDelivery, error) {
+	d.CreatedAt = time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, `
+	INSERT INTO webhook_deliveries(subscription_id, event, payload, status, attempts, next_attempt_at, created_at)
+	VALUES(?,?,?,?,?,?,?)`,
+		d.SubscriptionID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttemptAt.UTC(), d.CreatedAt)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	d.ID, err = res.LastInsertId()
+	return d, err
+}
+
+// UpdateWebhookDelivery saves outcome of a delivery attempt.
+func (s *SQLLiteStorage) UpdateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) error {
+	var deliveredAt interface{}
+	if d.DeliveredAt != nil {
+		deliveredAt = d.DeliveredAt.UTC()
+	}
+	_, err := s.db.ExecContext(ctx, `
+	UPDATE webhook_deliveries
+	SET status = ?, attempts = ?, response_code = ?, error = ?, next_attempt_at = ?, delivered_at = ?
+	WHERE id = ?`,
+		d.Status, d.Attempts, d.ResponseCode, d.Error, d.NextAttemptAt.UTC(), deliveredAt, d.ID)
+	return err
+}
+
+func (s *SQLLiteStorage) FindWebhookDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = ?`, id)
+	d, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WebhookDelivery{}, entity.ErrNotFound
+	}
+	return d, err
+}
+
+// ListWebhookDeliveries returns delivery log of the subscription, newest first.
+func (s *SQLLiteStorage) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error) {
+	return queryDeliveries(ctx, s.db, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+	WHERE subscription_id = ? ORDER BY id DESC LIMIT ?`, subscriptionID, limit)
+}
+
+// DueWebhookDeliveries returns pending deliveries whose next attempt time has come, oldest first.
+// lease is ignored: the SQLite database belongs to a single replica, nobody else claims its deliveries.
+func (s *SQLLiteStorage) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	return queryDeliveries(ctx, s.db, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+	WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?`, entity.DeliveryPending, now.UTC(), limit)
+}
+
+const deliveryColumns = `id, subscription_id, event, payload, status, attempts, response_code, error, next_attempt_at, delivered_at, created_at`
+
+func queryDeliveries(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]entity.WebhookDelivery, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDelivery(row scanner) (entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	var deliveredAt sql.NullTime
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.Event, &d.Payload, &d.Status, &d.Attempts,
+		&d.ResponseCode, &d.Error, &d.NextAttemptAt, &deliveredAt, &d.CreatedAt)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return d, nil
+}
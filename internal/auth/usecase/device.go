@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
+)
+
+const (
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// RFC 8628 section 6.1: no vowels so codes don't form words, 20^8 combinations
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+	// new user code is generated that many times when it collides with a pending one
+	userCodeAttempts = 5
+	// interval is increased by that much on every slow_down, RFC 8628 section 3.5
+	slowDownStep = 5 * time.Second
+)
+
+type DeviceAuthorizationRepository interface {
+	SaveDeviceAuthorization(ctx context.Context, d entity.DeviceAuthorization) error
+	FindDeviceAuthorization(ctx context.Context, userCode string) (entity.DeviceAuthorization, error)
+	DecideDeviceAuthorization(ctx context.Context, userCode, status, username, tenant string) error
+	PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (entity.DeviceAuthorization, error)
+	SlowDownDevicePolling(ctx context.Context, deviceCodeHash string, interval time.Duration) error
+	ConsumeDeviceAuthorization(ctx context.Context, deviceCodeHash string) error
+}
+
+// PostDeviceCode starts RFC 8628 flow: the device shows user_code and polls /token with device_code
+// while the user approves the request on the verification page.
+func (u AuthUseCase) PostDeviceCode(ctx context.Context, request gen.PostDeviceCodeRequestObject) (gen.PostDeviceCodeResponseObject, error) {
+	if u.devices == nil {
+		return gen.PostDeviceCode400JSONResponse{Error: "unsupported_grant_type"}, nil
+	}
+	if request.Body.ClientId == "" {
+		return gen.PostDeviceCode400JSONResponse{Error: "invalid_request", ErrorDescription: optional("client_id is required")}, nil
+	}
+
+	deviceCode, err := newDeviceCode()
+	if err != nil {
+		return gen.PostDeviceCode500JSONResponse{Error: "server_error"}, err
+	}
+	var userCode string
+	for attempt := 0; ; attempt++ {
+		if userCode, err = newUserCode(); err != nil {
+			return gen.PostDeviceCode500JSONResponse{Error: "server_error"}, err
+		}
+		err = u.devices.SaveDeviceAuthorization(ctx, entity.DeviceAuthorization{
+			DeviceCodeHash: hashDeviceCode(deviceCode),
+			UserCode:       userCode,
+			ClientID:       request.Body.ClientId,
+			Scope:          strings.Join(strings.Fields(stringValue(request.Body.Scope)), " "),
+			Interval:       u.devicePollInterval,
+			ExpiresAt:      time.Now().Add(u.deviceCodeTTL),
+		})
+		if !errors.Is(err, entity.ErrAlreadyExists) || attempt+1 == userCodeAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return gen.PostDeviceCode500JSONResponse{Error: "server_error"}, nil
+	}
+
+	complete := u.deviceVerificationURL + "?user_code=" + url.QueryEscape(formatUserCode(userCode))
+	return gen.PostDeviceCode200JSONResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                formatUserCode(userCode),
+		VerificationUri:         u.deviceVerificationURL,
+		VerificationUriComplete: &complete,
+		ExpiresIn:               int(u.deviceCodeTTL.Seconds()),
+		Interval:                int(u.devicePollInterval.Seconds()),
+	}, nil
+}
+
+// GetDevice shows the logged in user what they are about to approve.
+func (u AuthUseCase) GetDevice(ctx context.Context, request gen.GetDeviceRequestObject) (gen.GetDeviceResponseObject, error) {
+	if _, ok := middleware.IdentityFromContext(ctx); !ok {
+		return gen.GetDevice401JSONResponse{Error: "unauth"}, nil
+	}
+	if u.devices == nil {
+		return gen.GetDevice404JSONResponse{Error: "device authorization disabled"}, nil
+	}
+
+	d, err := u.devices.FindDeviceAuthorization(ctx, normalizeUserCode(request.Params.UserCode))
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.GetDevice404JSONResponse{Error: "unknown or expired code"}, nil
+	}
+	if err != nil {
+		return gen.GetDevice500JSONResponse{}, nil
+	}
+
+	return gen.GetDevice200JSONResponse{
+		UserCode:  formatUserCode(d.UserCode),
+		ClientId:  d.ClientID,
+		Scope:     d.Scope,
+		ExpiresAt: d.ExpiresAt,
+	}, nil
+}
+
+// PostDevice approves or denies device request on behalf of the logged in user.
+// Impersonation tokens can't do it: the device would get a token the user never agreed to.
+func (u AuthUseCase) PostDevice(ctx context.Context, request gen.PostDeviceRequestObject) (gen.PostDeviceResponseObject, error) {
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return gen.PostDevice401JSONResponse{Error: "unauth"}, nil
+	}
+	if identity.Impersonated() {
+		return gen.PostDevice403JSONResponse{Error: "forbidden"}, nil
+	}
+	if u.devices == nil {
+		return gen.PostDevice404JSONResponse{Error: "device authorization disabled"}, nil
+	}
+	if request.Body.UserCode == "" {
+		return gen.PostDevice400JSONResponse{Error: "userCode is required"}, nil
+	}
+
+	userCode := normalizeUserCode(request.Body.UserCode)
+	d, err := u.devices.FindDeviceAuthorization(ctx, userCode)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PostDevice404JSONResponse{Error: "unknown or expired code"}, nil
+	}
+	if err != nil {
+		return gen.PostDevice500JSONResponse{}, nil
+	}
+
+	status, result := entity.DeviceStatusApproved, entity.AuditResultSuccess
+	if !request.Body.Approve {
+		status, result = entity.DeviceStatusDenied, entity.AuditResultDenied
+	}
+	err = u.devices.DecideDeviceAuthorization(ctx, userCode, status, identity.Subject, identity.Tenant)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PostDevice404JSONResponse{Error: "unknown or expired code"}, nil
+	}
+	if err != nil {
+		return gen.PostDevice500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditDeviceAuthorize, Target: d.ClientID, Result: result, Details: "scope: " + d.Scope})
+	return gen.PostDevice204Response{}, nil
+}
+
+// pollDevice is the device side of the flow at /token, RFC 8628 section 3.4.
+func (u AuthUseCase) pollDevice(ctx context.Context, body *gen.TokenRequest) (gen.PostTokenResponseObject, error) {
+	deviceCode := stringValue(body.DeviceCode)
+	if deviceCode == "" || stringValue(body.ClientId) == "" {
+		return oauthError("invalid_request", "device_code and client_id are required"), nil
+	}
+
+	now := time.Now()
+	hash := hashDeviceCode(deviceCode)
+	d, err := u.devices.PollDeviceAuthorization(ctx, hash, now)
+	if errors.Is(err, entity.ErrNotFound) {
+		return oauthError("invalid_grant", "unknown device_code"), nil
+	}
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, nil
+	}
+	if d.ClientID != *body.ClientId {
+		return oauthError("invalid_grant", "device_code was issued to another client"), nil
+	}
+	if !now.Before(d.ExpiresAt) {
+		return oauthError("expired_token", ""), nil
+	}
+
+	switch d.Status {
+	case entity.DeviceStatusPending:
+		if d.LastPolledAt != nil && now.Sub(*d.LastPolledAt) < d.Interval {
+			if err := u.devices.SlowDownDevicePolling(ctx, hash, d.Interval+slowDownStep); err != nil {
+				return gen.PostToken500JSONResponse{Error: "server_error"}, nil
+			}
+			return oauthError("slow_down", ""), nil
+		}
+		return oauthError("authorization_pending", ""), nil
+	case entity.DeviceStatusDenied:
+		return oauthError("access_denied", ""), nil
+	case entity.DeviceStatusConsumed:
+		return oauthError("invalid_grant", "device_code has already been used"), nil
+	}
+
+	// only one of concurrent polls gets the token
+	err = u.devices.ConsumeDeviceAuthorization(ctx, hash)
+	if errors.Is(err, entity.ErrNotFound) {
+		return oauthError("invalid_grant", "device_code has already been used"), nil
+	}
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, nil
+	}
+
+	user, err := u.ur.FindUserByEmail(ctx, d.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return oauthError("invalid_grant", "user no longer exists"), nil
+	}
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, nil
+	}
+	if !user.Active() {
+		return oauthError("invalid_grant", "account is disabled"), nil
+	}
+
+	claims := jwtmanager.NewClaims(user.Username).
+		WithTenant(d.Tenant).
+		WithRole(user.Role).
+		WithScopes(strings.Fields(d.Scope)...).
+		WithClaim("client_id", d.ClientID).
+		WithTTL(u.deviceTokenTTL)
+	token, err := u.tokens.IssueToken(claims)
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, err
+	}
+
+	u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Target: d.Tenant, Result: entity.AuditResultSuccess, Details: "device: " + d.ClientID})
+	u.updateLastLogin(ctx, user.Username)
+
+	return gen.PostToken200JSONResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(u.deviceTokenTTL.Seconds()),
+		Scope:       optional(d.Scope),
+	}, nil
+}
+
+func newDeviceCode() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashDeviceCode - device codes are bearer credentials, so only their hashes are stored.
+func hashDeviceCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func newUserCode() (string, error) {
+	code := make([]byte, userCodeLength)
+	alphabet := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabet)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// formatUserCode splits code in halves for readability: BDWPHQPK -> BDWP-HQPK.
+func formatUserCode(code string) string {
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// normalizeUserCode makes user input comparable with stored codes: case and separators don't matter.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
+)
+
+func (u AuthUseCase) PostAdminImpersonate(ctx context.Context, request gen.PostAdminImpersonateRequestObject) (gen.PostAdminImpersonateResponseObject, error) {
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return gen.PostAdminImpersonate401JSONResponse{Error: "unauth"}, nil
+	}
+	// impersonation tokens can't be used to impersonate someone else
+	if identity.Impersonated() {
+		return gen.PostAdminImpersonate403JSONResponse{Error: "forbidden"}, nil
+	}
+	if request.Body.Username == "" || request.Body.Reason == "" {
+		return gen.PostAdminImpersonate400JSONResponse{Error: "username and reason are required"}, nil
+	}
+
+	// global admins act instance-wide, org admins only inside the org their token is scoped to
+	var org entity.Organization
+	var err error
+	if identity.Tenant == "" {
+		err = u.requireGlobalAdmin(ctx)
+	} else {
+		org, err = u.authorizeOrgAdmin(ctx, identity.Tenant)
+	}
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PostAdminImpersonate401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden), errors.Is(err, entity.ErrNotFound):
+		u.record(ctx, entity.AuditEvent{Action: entity.AuditImpersonate, Target: request.Body.Username, Result: entity.AuditResultDenied, Details: request.Body.Reason})
+		return gen.PostAdminImpersonate403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.PostAdminImpersonate500JSONResponse{}, nil
+	}
+
+	target, err := u.ur.FindUserByEmail(ctx, request.Body.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PostAdminImpersonate404JSONResponse{Error: "user not found"}, nil
+	}
+	if err != nil {
+		return gen.PostAdminImpersonate500JSONResponse{}, nil
+	}
+	if target.Role == entity.RoleAdmin {
+		return gen.PostAdminImpersonate403JSONResponse{Error: "admins can't be impersonated"}, nil
+	}
+	if !target.Active() {
+		u.record(ctx, entity.AuditEvent{Action: entity.AuditImpersonate, Target: target.Username, Result: entity.AuditResultDenied, Details: "account is " + target.Status})
+		return gen.PostAdminImpersonate403JSONResponse{Error: errorAccountDisabled}, nil
+	}
+
+	if identity.Tenant != "" {
+		membership, err := u.orgs.FindMembership(ctx, org.ID, target.Username)
+		if errors.Is(err, entity.ErrNotFound) {
+			return gen.PostAdminImpersonate404JSONResponse{Error: "user not found"}, nil
+		}
+		if err != nil {
+			return gen.PostAdminImpersonate500JSONResponse{}, nil
+		}
+		if membership.Role == entity.OrgRoleAdmin {
+			return gen.PostAdminImpersonate403JSONResponse{Error: "admins can't be impersonated"}, nil
+		}
+	}
+
+	claims := jwtmanager.NewClaims(target.Username).
+		WithActor(identity.Subject).
+		WithTenant(identity.Tenant).
+		WithRole(target.Role).
+		WithTTL(u.impersonationTTL)
+	token, err := u.tokens.IssueToken(claims)
+	if err != nil {
+		return gen.PostAdminImpersonate500JSONResponse{}, err
+	}
+
+	u.record(ctx, entity.AuditEvent{
+		Action:  entity.AuditImpersonate,
+		Target:  target.Username,
+		Result:  entity.AuditResultSuccess,
+		Details: fmt.Sprintf("reason: %s; tenant: %s; ttl: %s", request.Body.Reason, identity.Tenant, u.impersonationTTL),
+	})
+
+	return gen.PostAdminImpersonate200JSONResponse{
+		AccessToken: token,
+		ExpiresIn:   int(u.impersonationTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+)
+
+func (u AuthUseCase) GetWellKnownJwksJson(ctx context.Context, request gen.GetWellKnownJwksJsonRequestObject) (gen.GetWellKnownJwksJsonResponseObject, error) {
+	keys := u.jm.PublicKeys()
+	resp := gen.GetWellKnownJwksJson200JSONResponse{Keys: make([]gen.JWK, 0, len(keys))}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, gen.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Alg: k.Alg,
+			Use: k.Use,
+			Crv: optional(k.Crv),
+			X:   optional(k.X),
+			Y:   optional(k.Y),
+			N:   optional(k.N),
+			E:   optional(k.E),
+		})
+	}
+	return resp, nil
+}
+
+// optional omits empty values from the response.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
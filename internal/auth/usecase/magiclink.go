@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
+)
+
+const magicLinkPurpose = "magic_link"
+
+type MagicLinkRepository interface {
+	SaveMagicLink(ctx context.Context, l entity.MagicLink) error
+	ConsumeMagicLink(ctx context.Context, id string) (entity.MagicLink, error)
+	// MarkUserVerified writes user.verified to the outbox in the same transaction when the user becomes verified
+	MarkUserVerified(ctx context.Context, username string, at time.Time) (bool, error)
+}
+
+type Notifier interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func (u AuthUseCase) PostLoginMagic(ctx context.Context, request gen.PostLoginMagicRequestObject) (gen.PostLoginMagicResponseObject, error) {
+	if u.ml == nil {
+		return gen.PostLoginMagic404JSONResponse{Error: "magic link login disabled"}, nil
+	}
+	if request.Body.Username == "" {
+		return gen.PostLoginMagic400JSONResponse{Error: "username is required"}, nil
+	}
+
+	user, err := u.ur.FindUserByEmail(ctx, request.Body.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		u.record(ctx, entity.AuditEvent{Actor: request.Body.Username, Action: entity.AuditMagicLinkRequest, Result: entity.AuditResultFailure, Details: "unknown user"})
+		// same answer as for existing users, don't let anyone probe usernames
+		return gen.PostLoginMagic202Response{}, nil
+	}
+	if err != nil {
+		return gen.PostLoginMagic500JSONResponse{}, nil
+	}
+	if !user.Active() {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditMagicLinkRequest, Result: entity.AuditResultDenied, Details: "account is " + user.Status})
+		return gen.PostLoginMagic202Response{}, nil
+	}
+
+	token, id, err := u.jm.IssueOneTimeToken(user.Username, magicLinkPurpose, u.magicLinkTTL)
+	if err != nil {
+		return gen.PostLoginMagic500JSONResponse{}, err
+	}
+
+	err = u.ml.SaveMagicLink(ctx, entity.MagicLink{
+		ID:        id,
+		Username:  user.Username,
+		ExpiresAt: time.Now().Add(u.magicLinkTTL),
+	})
+	if err != nil {
+		return gen.PostLoginMagic500JSONResponse{}, nil
+	}
+
+	link := u.magicLinkURL + "?token=" + url.QueryEscape(token)
+	if err := u.notifier.Send(ctx, user.Username, "Your login link", "Follow the link to log in: "+link); err != nil {
+		return gen.PostLoginMagic500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditMagicLinkRequest, Result: entity.AuditResultSuccess})
+
+	return gen.PostLoginMagic202Response{}, nil
+}
+
+func (u AuthUseCase) GetLoginMagicVerify(ctx context.Context, request gen.GetLoginMagicVerifyRequestObject) (gen.GetLoginMagicVerifyResponseObject, error) {
+	if u.ml == nil {
+		return gen.GetLoginMagicVerify404JSONResponse{Error: "magic link login disabled"}, nil
+	}
+
+	username, id, err := u.jm.VerifyOneTimeToken(request.Params.Token, magicLinkPurpose)
+	if err != nil {
+		u.record(ctx, entity.AuditEvent{Action: entity.AuditMagicLinkLogin, Result: entity.AuditResultFailure, Details: "invalid token"})
+		return gen.GetLoginMagicVerify401JSONResponse{Error: "invalid token"}, nil
+	}
+
+	link, err := u.ml.ConsumeMagicLink(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		u.record(ctx, entity.AuditEvent{Actor: username, Action: entity.AuditMagicLinkLogin, Result: entity.AuditResultFailure, Details: "link already used or expired"})
+		return gen.GetLoginMagicVerify401JSONResponse{Error: "invalid token"}, nil
+	}
+	if err != nil {
+		return gen.GetLoginMagicVerify500JSONResponse{}, nil
+	}
+	if link.Username != username {
+		return gen.GetLoginMagicVerify401JSONResponse{Error: "invalid token"}, nil
+	}
+	// the account may have been disabled after the link was sent
+	user, err := u.ur.FindUserByEmail(ctx, username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.GetLoginMagicVerify401JSONResponse{Error: "invalid token"}, nil
+	}
+	if err != nil {
+		return gen.GetLoginMagicVerify500JSONResponse{}, nil
+	}
+	if !user.Active() {
+		u.record(ctx, entity.AuditEvent{Actor: username, Action: entity.AuditMagicLinkLogin, Result: entity.AuditResultDenied, Details: "account is " + user.Status})
+		return gen.GetLoginMagicVerify403JSONResponse{Error: errorAccountDisabled}, nil
+	}
+	// following the link proves the user owns the address, user.verified is published the first time only
+	verified, err := u.ml.MarkUserVerified(ctx, username, time.Now())
+	if err != nil {
+		return gen.GetLoginMagicVerify500JSONResponse{}, nil
+	}
+
+	token, err := u.tokens.IssueToken(jwtmanager.NewClaims(username))
+	if err != nil {
+		return gen.GetLoginMagicVerify500JSONResponse{}, err
+	}
+
+	u.record(ctx, entity.AuditEvent{Actor: username, Action: entity.AuditMagicLinkLogin, Result: entity.AuditResultSuccess})
+	u.updateLastLogin(ctx, username)
+	if verified {
+		u.publish(ctx, entity.EventUserVerified, entity.UserEventData{Username: username})
+	}
+
+	return gen.GetLoginMagicVerify200JSONResponse{
+		AccessToken: token,
+	}, nil
+}
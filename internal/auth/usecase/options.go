@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Option configures optional AuthUseCase features.
+type Option func(*AuthUseCase)
+
+// WithMagicLink enables passwordless login: links are valid for ttl and point to baseURL?token=...
+func WithMagicLink(repo MagicLinkRepository, n Notifier, baseURL string, ttl time.Duration) Option {
+	return func(u *AuthUseCase) {
+		u.ml = repo
+		u.notifier = n
+		u.magicLinkURL = baseURL
+		u.magicLinkTTL = ttl
+	}
+}
+
+// WithDeviceAuthorization enables RFC 8628 device flow: codes are valid for ttl, devices poll /token not
+// more often than interval and get tokens living tokenTTL. verificationURL is the page where users enter codes.
+func WithDeviceAuthorization(repo DeviceAuthorizationRepository, verificationURL string, ttl, interval, tokenTTL time.Duration) Option {
+	return func(u *AuthUseCase) {
+		u.devices = repo
+		u.deviceVerificationURL = verificationURL
+		u.deviceCodeTTL = ttl
+		u.devicePollInterval = interval
+		u.deviceTokenTTL = tokenTTL
+	}
+}
+
+// WithTokenIssuer changes format of access tokens, one-time tokens stay JWT.
+func WithTokenIssuer(t TokenIssuer) Option {
+	return func(u *AuthUseCase) {
+		u.tokens = t
+	}
+}
+
+func WithLogger(log *slog.Logger) Option {
+	return func(u *AuthUseCase) {
+		u.log = log
+	}
+}
+
+// WithImpersonationTTL sets lifetime of tokens issued by admins to act as other users.
+func WithImpersonationTTL(ttl time.Duration) Option {
+	return func(u *AuthUseCase) {
+		u.impersonationTTL = ttl
+	}
+}
+
+// ExchangeGrant - audiences and scopes an actor may request in token exchange.
+type ExchangeGrant struct {
+	Audiences []string
+	Scopes    []string
+}
+
+// WithTokenExchange enables RFC 8693 token exchange at /token, exchanged tokens live at most ttl.
+// Only actors listed in actors can exchange tokens, keyed by subject of the actor token.
+func WithTokenExchange(ttl time.Duration, actors map[string]ExchangeGrant) Option {
+	return func(u *AuthUseCase) {
+		u.tokenExchangeTTL = ttl
+		u.exchangeActors = actors
+	}
+}
+
+// WithPasswordPolicy rejects weak passwords on registration.
+func WithPasswordPolicy(p PasswordPolicy) Option {
+	return func(u *AuthUseCase) {
+		u.passwordPolicy = p
+	}
+}
+
+// WithPasswordRotation forbids reusing the last history passwords and makes passwords older
+// than maxAge unusable for login until changed. Zero disables the corresponding check.
+func WithPasswordRotation(history int, maxAge time.Duration) Option {
+	return func(u *AuthUseCase) {
+		u.passwordHistory = history
+		u.passwordMaxAge = maxAge
+	}
+}
+
+// WithOrganizations enables multi-tenancy: org membership, tenant-scoped tokens and org admin APIs.
+func WithOrganizations(repo OrganizationRepository) Option {
+	return func(u *AuthUseCase) {
+		u.orgs = repo
+	}
+}
+
+// WithAuditLog stores security events in repo instead of just logging them.
+func WithAuditLog(repo AuditRepository) Option {
+	return func(u *AuthUseCase) {
+		u.audit = repo
+	}
+}
+
+// WithWebhooks notifies external subscribers about user lifecycle events and enables the admin API to manage them.
+func WithWebhooks(repo WebhookRepository, d WebhookDispatcher) Option {
+	return func(u *AuthUseCase) {
+		u.webhookRepo = repo
+		u.webhooks = d
+	}
+}
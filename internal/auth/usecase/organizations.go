@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+)
+
+var (
+	errUnauthenticated = errors.New("unauthenticated")
+	errForbidden       = errors.New("forbidden")
+)
+
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, o entity.Organization) (entity.Organization, error)
+	FindOrganization(ctx context.Context, slug string) (entity.Organization, error)
+	UpdateOrganizationSettings(ctx context.Context, orgID int64, settings entity.OrganizationSettings) error
+	SaveMembership(ctx context.Context, m entity.Membership) error
+	FindMembership(ctx context.Context, orgID int64, username string) (entity.Membership, error)
+	ListMembers(ctx context.Context, orgID int64) ([]entity.Membership, error)
+}
+
+func (u AuthUseCase) PostOrgs(ctx context.Context, request gen.PostOrgsRequestObject) (gen.PostOrgsResponseObject, error) {
+	if u.orgs == nil {
+		return gen.PostOrgs403JSONResponse{Error: "organizations disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PostOrgs401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PostOrgs403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.PostOrgs500JSONResponse{}, nil
+	}
+
+	if request.Body.Slug == "" || request.Body.Name == "" {
+		return gen.PostOrgs400JSONResponse{Error: "slug and name are required"}, nil
+	}
+	settings := entity.OrganizationSettings{RegistrationPolicy: entity.RegistrationClosed}
+	if request.Body.Settings != nil && request.Body.Settings.RegistrationPolicy != nil {
+		settings.RegistrationPolicy = string(*request.Body.Settings.RegistrationPolicy)
+	}
+	if !validRegistrationPolicy(settings.RegistrationPolicy) {
+		return gen.PostOrgs400JSONResponse{Error: "unknown registration policy"}, nil
+	}
+
+	org, err := u.orgs.CreateOrganization(ctx, entity.Organization{
+		Slug:     request.Body.Slug,
+		Name:     request.Body.Name,
+		Settings: settings,
+	})
+	if errors.Is(err, entity.ErrAlreadyExists) {
+		return gen.PostOrgs409JSONResponse{Error: "organization already exists"}, nil
+	}
+	if err != nil {
+		return gen.PostOrgs500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditOrgCreate, Target: org.Slug, Result: entity.AuditResultSuccess})
+
+	return gen.PostOrgs201JSONResponse(toGenOrganization(org)), nil
+}
+
+func (u AuthUseCase) GetOrgsSlug(ctx context.Context, request gen.GetOrgsSlugRequestObject) (gen.GetOrgsSlugResponseObject, error) {
+	org, err := u.authorizeOrgAdmin(ctx, request.Slug)
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return gen.GetOrgsSlug401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.GetOrgsSlug403JSONResponse{Error: "forbidden"}, nil
+	case errors.Is(err, entity.ErrNotFound):
+		return gen.GetOrgsSlug404JSONResponse{Error: "organization not found"}, nil
+	case err != nil:
+		return gen.GetOrgsSlug500JSONResponse{}, nil
+	}
+
+	return gen.GetOrgsSlug200JSONResponse(toGenOrganization(org)), nil
+}
+
+func (u AuthUseCase) PatchOrgsSlugSettings(ctx context.Context, request gen.PatchOrgsSlugSettingsRequestObject) (gen.PatchOrgsSlugSettingsResponseObject, error) {
+	org, err := u.authorizeOrgAdmin(ctx, request.Slug)
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PatchOrgsSlugSettings401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PatchOrgsSlugSettings403JSONResponse{Error: "forbidden"}, nil
+	case errors.Is(err, entity.ErrNotFound):
+		return gen.PatchOrgsSlugSettings404JSONResponse{Error: "organization not found"}, nil
+	case err != nil:
+		return gen.PatchOrgsSlugSettings500JSONResponse{}, nil
+	}
+
+	if request.Body.RegistrationPolicy != nil {
+		org.Settings.RegistrationPolicy = string(*request.Body.RegistrationPolicy)
+	}
+	if !validRegistrationPolicy(org.Settings.RegistrationPolicy) {
+		return gen.PatchOrgsSlugSettings400JSONResponse{Error: "unknown registration policy"}, nil
+	}
+
+	if err := u.orgs.UpdateOrganizationSettings(ctx, org.ID, org.Settings); err != nil {
+		return gen.PatchOrgsSlugSettings500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{
+		Action:  entity.AuditOrgSettingsUpdate,
+		Target:  org.Slug,
+		Result:  entity.AuditResultSuccess,
+		Details: "registration policy: " + org.Settings.RegistrationPolicy,
+	})
+
+	return gen.PatchOrgsSlugSettings200JSONResponse(toGenOrganization(org)), nil
+}
+
+func (u AuthUseCase) GetOrgsSlugMembers(ctx context.Context, request gen.GetOrgsSlugMembersRequestObject) (gen.GetOrgsSlugMembersResponseObject, error) {
+	org, err := u.authorizeOrgAdmin(ctx, request.Slug)
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return gen.GetOrgsSlugMembers401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.GetOrgsSlugMembers403JSONResponse{Error: "forbidden"}, nil
+	case errors.Is(err, entity.ErrNotFound):
+		return gen.GetOrgsSlugMembers404JSONResponse{Error: "organization not found"}, nil
+	case err != nil:
+		return gen.GetOrgsSlugMembers500JSONResponse{}, nil
+	}
+
+	members, err := u.orgs.ListMembers(ctx, org.ID)
+	if err != nil {
+		return gen.GetOrgsSlugMembers500JSONResponse{}, nil
+	}
+
+	resp := make(gen.GetOrgsSlugMembers200JSONResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, gen.Membership{Username: m.Username, Role: gen.OrgRole(m.Role)})
+	}
+	return resp, nil
+}
+
+func (u AuthUseCase) PutOrgsSlugMembersUsername(ctx context.Context, request gen.PutOrgsSlugMembersUsernameRequestObject) (gen.PutOrgsSlugMembersUsernameResponseObject, error) {
+	org, err := u.authorizeOrgAdmin(ctx, request.Slug)
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PutOrgsSlugMembersUsername401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PutOrgsSlugMembersUsername403JSONResponse{Error: "forbidden"}, nil
+	case errors.Is(err, entity.ErrNotFound):
+		return gen.PutOrgsSlugMembersUsername404JSONResponse{Error: "organization not found"}, nil
+	case err != nil:
+		return gen.PutOrgsSlugMembersUsername500JSONResponse{}, nil
+	}
+
+	role := string(request.Body.Role)
+	if role != entity.OrgRoleMember && role != entity.OrgRoleAdmin {
+		return gen.PutOrgsSlugMembersUsername400JSONResponse{Error: "unknown role"}, nil
+	}
+
+	_, err = u.ur.FindUserByEmail(ctx, request.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PutOrgsSlugMembersUsername404JSONResponse{Error: "user not found"}, nil
+	}
+	if err != nil {
+		return gen.PutOrgsSlugMembersUsername500JSONResponse{}, nil
+	}
+
+	err = u.orgs.SaveMembership(ctx, entity.Membership{OrgID: org.ID, Username: request.Username, Role: role})
+	if err != nil {
+		return gen.PutOrgsSlugMembersUsername500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{
+		Action:  entity.AuditOrgMembershipSave,
+		Target:  request.Username,
+		Result:  entity.AuditResultSuccess,
+		Details: fmt.Sprintf("org: %s; role: %s", org.Slug, role),
+	})
+
+	return gen.PutOrgsSlugMembersUsername200JSONResponse{
+		Username: request.Username,
+		Role:     gen.OrgRole(role),
+	}, nil
+}
+
+// requireGlobalAdmin lets through instance-wide admins using an unscoped token.
+func (u AuthUseCase) requireGlobalAdmin(ctx context.Context) error {
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return errUnauthenticated
+	}
+	// impersonation and exchanged tokens act on behalf of the user, they never carry admin rights
+	if identity.Tenant != "" || identity.Impersonated() {
+		return errForbidden
+	}
+
+	user, err := u.activeCaller(ctx, identity.Subject)
+	if err != nil {
+		return err
+	}
+	if user.Role != entity.RoleAdmin {
+		return errForbidden
+	}
+	return nil
+}
+
+// activeCaller loads account of the token subject, tokens of disabled accounts stay valid until expiry
+// but don't grant admin rights anymore.
+func (u AuthUseCase) activeCaller(ctx context.Context, username string) (entity.UserAccount, error) {
+	user, err := u.ur.FindUserByEmail(ctx, username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.UserAccount{}, errUnauthenticated
+	}
+	if err != nil {
+		return entity.UserAccount{}, err
+	}
+	if !user.Active() {
+		return entity.UserAccount{}, errForbidden
+	}
+	return user, nil
+}
+
+// authorizeOrgAdmin loads organization if caller may administer it: either global admin
+// with unscoped token or org admin with token scoped to this very organization.
+func (u AuthUseCase) authorizeOrgAdmin(ctx context.Context, slug string) (entity.Organization, error) {
+	if u.orgs == nil {
+		return entity.Organization{}, errForbidden
+	}
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return entity.Organization{}, errUnauthenticated
+	}
+	if identity.Impersonated() {
+		return entity.Organization{}, errForbidden
+	}
+
+	if identity.Tenant == "" {
+		if err := u.requireGlobalAdmin(ctx); err != nil {
+			return entity.Organization{}, err
+		}
+		return u.orgs.FindOrganization(ctx, slug)
+	}
+
+	if identity.Tenant != slug {
+		return entity.Organization{}, errForbidden
+	}
+	org, err := u.orgs.FindOrganization(ctx, slug)
+	if err != nil {
+		return entity.Organization{}, err
+	}
+	membership, err := u.orgs.FindMembership(ctx, org.ID, identity.Subject)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.Organization{}, errForbidden
+	}
+	if err != nil {
+		return entity.Organization{}, err
+	}
+	if membership.Role != entity.OrgRoleAdmin {
+		return entity.Organization{}, errForbidden
+	}
+	if _, err := u.activeCaller(ctx, identity.Subject); err != nil {
+		return entity.Organization{}, err
+	}
+	return org, nil
+}
+
+func validRegistrationPolicy(p string) bool {
+	return p == entity.RegistrationOpen || p == entity.RegistrationClosed
+}
+
+func toGenOrganization(o entity.Organization) gen.Organization {
+	policy := gen.RegistrationPolicy(o.Settings.RegistrationPolicy)
+	return gen.Organization{
+		Id:   int(o.ID),
+		Slug: o.Slug,
+		Name: o.Name,
+		Settings: gen.OrganizationSettings{
+			RegistrationPolicy: &policy,
+		},
+	}
+}
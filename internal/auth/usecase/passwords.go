@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+)
+
+// errorPasswordExpired is returned by PostLogin, the client is expected to call /password/change
+const errorPasswordExpired = "password_expired"
+
+// errorAccountDisabled is returned by PostLogin for accounts that aren't active
+const errorAccountDisabled = "account_disabled"
+
+var errPasswordReused = errors.New("password was used recently, choose another one")
+
+func (u AuthUseCase) PostPasswordChange(ctx context.Context, request gen.PostPasswordChangeRequestObject) (gen.PostPasswordChangeResponseObject, error) {
+	user, err := u.ur.FindUserByEmail(ctx, request.Body.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		u.record(ctx, entity.AuditEvent{Actor: request.Body.Username, Action: entity.AuditPasswordChange, Result: entity.AuditResultFailure, Details: "unknown user"})
+		return gen.PostPasswordChange401JSONResponse{Error: "unauth"}, nil
+	}
+	if err != nil {
+		return gen.PostPasswordChange500JSONResponse{}, nil
+	}
+	// expired passwords are still accepted here, that's the way out
+	if !u.cp.ComparePasswords(user.Password, request.Body.CurrentPassword) {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditPasswordChange, Result: entity.AuditResultFailure, Details: "wrong password"})
+		return gen.PostPasswordChange401JSONResponse{Error: "unauth"}, nil
+	}
+	if !user.Active() {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditPasswordChange, Result: entity.AuditResultDenied, Details: "account is " + user.Status})
+		return gen.PostPasswordChange403JSONResponse{Error: errorAccountDisabled}, nil
+	}
+
+	err = u.setPassword(ctx, user, request.Body.NewPassword)
+	if errors.Is(err, entity.ErrWeakPassword) || errors.Is(err, errPasswordReused) {
+		return gen.PostPasswordChange400JSONResponse{Error: err.Error()}, nil
+	}
+	if err != nil {
+		u.log.ErrorContext(ctx, "change password", slog.Any("err", err))
+		return gen.PostPasswordChange500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditPasswordChange, Result: entity.AuditResultSuccess})
+	u.publish(ctx, entity.EventUserPasswordChanged, entity.UserEventData{Username: user.Username})
+
+	return gen.PostPasswordChange204Response{}, nil
+}
+
+func (u AuthUseCase) PostAdminUsersUsernamePassword(ctx context.Context, request gen.PostAdminUsersUsernamePasswordRequestObject) (gen.PostAdminUsersUsernamePasswordResponseObject, error) {
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PostAdminUsersUsernamePassword401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PostAdminUsersUsernamePassword403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.PostAdminUsersUsernamePassword500JSONResponse{}, nil
+	}
+
+	user, err := u.ur.FindUserByEmail(ctx, request.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PostAdminUsersUsernamePassword404JSONResponse{Error: "user not found"}, nil
+	}
+	if err != nil {
+		return gen.PostAdminUsersUsernamePassword500JSONResponse{}, nil
+	}
+
+	err = u.setPassword(ctx, user, request.Body.NewPassword)
+	if errors.Is(err, entity.ErrWeakPassword) || errors.Is(err, errPasswordReused) {
+		return gen.PostAdminUsersUsernamePassword400JSONResponse{Error: err.Error()}, nil
+	}
+	if err != nil {
+		u.log.ErrorContext(ctx, "reset password", slog.Any("err", err))
+		return gen.PostAdminUsersUsernamePassword500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditPasswordReset, Target: user.Username, Result: entity.AuditResultSuccess})
+	u.publish(ctx, entity.EventUserPasswordChanged, entity.UserEventData{Username: user.Username})
+
+	return gen.PostAdminUsersUsernamePassword204Response{}, nil
+}
+
+// setPassword validates new password against the policy and the history and stores it.
+func (u AuthUseCase) setPassword(ctx context.Context, user entity.UserAccount, password string) error {
+	if err := u.checkPasswordPolicy(password); err != nil {
+		return err
+	}
+
+	if u.passwordHistory > 0 {
+		previous, err := u.ur.PasswordHistory(ctx, user.Username, u.passwordHistory-1)
+		if err != nil {
+			return err
+		}
+		for _, hash := range append([]string{user.Password}, previous...) {
+			if u.cp.ComparePasswords(hash, password) {
+				return errPasswordReused
+			}
+		}
+	}
+
+	hashed, err := u.cp.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	// current password counts as one of the last N, so N-1 previous are kept
+	return u.ur.ChangePassword(ctx, user.Username, string(hashed), max(u.passwordHistory-1, 0))
+}
+
+func (u AuthUseCase) checkPasswordPolicy(password string) error {
+	if u.passwordPolicy == nil {
+		return nil
+	}
+	return u.passwordPolicy.Check(password)
+}
+
+func (u AuthUseCase) passwordExpired(user entity.UserAccount) bool {
+	if u.passwordMaxAge <= 0 || user.PasswordChangedAt.IsZero() {
+		return false
+	}
+	return time.Since(user.PasswordChangedAt) > u.passwordMaxAge
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
+)
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// PostToken is OAuth 2.0 token endpoint, errors follow RFC 6749 section 5.2.
+func (u AuthUseCase) PostToken(ctx context.Context, request gen.PostTokenRequestObject) (gen.PostTokenResponseObject, error) {
+	switch request.Body.GrantType {
+	case grantTypeTokenExchange:
+		if u.tokenExchangeTTL > 0 {
+			return u.exchangeToken(ctx, request.Body)
+		}
+	case grantTypeDeviceCode:
+		if u.devices != nil {
+			return u.pollDevice(ctx, request.Body)
+		}
+	case "":
+		return oauthError("invalid_request", "grant_type is required"), nil
+	}
+	return oauthError("unsupported_grant_type", ""), nil
+}
+
+// exchangeToken implements RFC 8693: the caller gets a token for the same user limited to a narrower
+// audience and scopes, with itself recorded as actor. The caller is identified by actor_token or,
+// without it, by its own Bearer token.
+func (u AuthUseCase) exchangeToken(ctx context.Context, body *gen.TokenRequest) (gen.PostTokenResponseObject, error) {
+	if stringValue(body.SubjectToken) == "" {
+		return oauthError("invalid_request", "subject_token is required"), nil
+	}
+	if !accessTokenType(stringValue(body.SubjectTokenType)) {
+		return oauthError("invalid_request", "unsupported subject_token_type"), nil
+	}
+	if t := stringValue(body.RequestedTokenType); t != "" && t != tokenTypeAccessToken {
+		return oauthError("invalid_request", "unsupported requested_token_type"), nil
+	}
+
+	actor, errResp := u.exchangeActor(ctx, body)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	grant, ok := u.exchangeActors[actor]
+	if !ok {
+		u.record(ctx, entity.AuditEvent{Actor: actor, Action: entity.AuditTokenExchange, Result: entity.AuditResultDenied, Details: "actor is not allowed to exchange tokens"})
+		return oauthError("unauthorized_client", "actor is not allowed to exchange tokens"), nil
+	}
+
+	subject, err := u.tokens.VerifyToken(*body.SubjectToken)
+	if err != nil || subject.Subject == "" {
+		u.record(ctx, entity.AuditEvent{Actor: actor, Action: entity.AuditTokenExchange, Result: entity.AuditResultDenied, Details: "invalid subject token"})
+		return oauthError("invalid_grant", "invalid subject_token"), nil
+	}
+	// subject token outlives disabling of the account, new tokens mustn't be issued for it
+	user, err := u.ur.FindUserByEmail(ctx, subject.Subject)
+	if errors.Is(err, entity.ErrNotFound) {
+		return oauthError("invalid_grant", "user no longer exists"), nil
+	}
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, nil
+	}
+	if !user.Active() {
+		u.record(ctx, entity.AuditEvent{Actor: actor, Action: entity.AuditTokenExchange, Target: subject.Subject, Result: entity.AuditResultDenied, Details: "account is " + user.Status})
+		return oauthError("invalid_grant", "account is disabled"), nil
+	}
+
+	// audience and scopes can only be narrowed to what the subject token carries and the actor may request,
+	// subject token without aud or scope grants none of them
+	allowedAudience := intersect(subject.Audience, grant.Audiences)
+	audience := strings.Fields(stringValue(body.Audience))
+	if len(audience) == 0 {
+		audience = allowedAudience
+	}
+	if len(audience) == 0 || !subset(audience, allowedAudience) {
+		u.record(ctx, entity.AuditEvent{Actor: actor, Action: entity.AuditTokenExchange, Target: subject.Subject, Result: entity.AuditResultDenied, Details: "audience is not allowed"})
+		return oauthError("invalid_target", "audience is not allowed for subject_token"), nil
+	}
+	allowedScopes := intersect(subject.Scopes(), grant.Scopes)
+	scopes := strings.Fields(stringValue(body.Scope))
+	if len(scopes) == 0 {
+		scopes = allowedScopes
+	} else if !subset(scopes, allowedScopes) {
+		u.record(ctx, entity.AuditEvent{Actor: actor, Action: entity.AuditTokenExchange, Target: subject.Subject, Result: entity.AuditResultDenied, Details: "scope is not allowed"})
+		return oauthError("invalid_scope", "scope is not allowed for subject_token"), nil
+	}
+
+	// exchanged token never outlives the subject one
+	ttl := u.tokenExchangeTTL
+	if remaining := time.Until(subject.ExpiresAt.Time).Truncate(time.Second); remaining < ttl {
+		ttl = remaining
+	}
+	if ttl <= 0 {
+		return oauthError("invalid_grant", "subject_token is about to expire"), nil
+	}
+
+	claims := jwtmanager.NewClaims(subject.Subject).
+		WithTenant(subject.Tenant).
+		WithRole(subject.Role).
+		WithAudience(audience...).
+		WithScopes(scopes...).
+		WithDelegation(actor, subject.Actor).
+		WithTTL(ttl)
+	token, err := u.tokens.IssueToken(claims)
+	if err != nil {
+		return gen.PostToken500JSONResponse{Error: "server_error"}, err
+	}
+
+	u.record(ctx, entity.AuditEvent{
+		Actor:   actor,
+		Action:  entity.AuditTokenExchange,
+		Target:  subject.Subject,
+		Result:  entity.AuditResultSuccess,
+		Details: "audience: " + strings.Join(audience, " ") + "; scope: " + claims.Scope,
+	})
+
+	issuedType := tokenTypeAccessToken
+	return gen.PostToken200JSONResponse{
+		AccessToken:     token,
+		IssuedTokenType: &issuedType,
+		TokenType:       "Bearer",
+		ExpiresIn:       int(ttl.Seconds()),
+		Scope:           optional(claims.Scope),
+	}, nil
+}
+
+// exchangeActor returns subject of the party the token is exchanged for. Tokens that are already
+// delegated or impersonated can't act themselves, chains are built from subject tokens only.
+func (u AuthUseCase) exchangeActor(ctx context.Context, body *gen.TokenRequest) (string, gen.PostTokenResponseObject) {
+	if stringValue(body.ActorToken) == "" {
+		identity, ok := middleware.IdentityFromContext(ctx)
+		if !ok {
+			return "", oauthError("invalid_request", "actor_token or Bearer authentication is required")
+		}
+		if identity.Impersonated() {
+			return "", oauthError("invalid_request", "actor can't be delegated")
+		}
+		return identity.Subject, nil
+	}
+
+	if !accessTokenType(stringValue(body.ActorTokenType)) {
+		return "", oauthError("invalid_request", "unsupported actor_token_type")
+	}
+	actor, err := u.tokens.VerifyToken(*body.ActorToken)
+	if err != nil || actor.Subject == "" {
+		return "", oauthError("invalid_grant", "invalid actor_token")
+	}
+	if actor.Actor != nil {
+		return "", oauthError("invalid_request", "actor can't be delegated")
+	}
+	return actor.Subject, nil
+}
+
+func accessTokenType(t string) bool {
+	return t == tokenTypeAccessToken || t == tokenTypeJWT
+}
+
+func subset(values, of []string) bool {
+	for _, v := range values {
+		if !slices.Contains(of, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func intersect(values, with []string) []string {
+	var common []string
+	for _, v := range values {
+		if slices.Contains(with, v) {
+			common = append(common, v)
+		}
+	}
+	return common
+}
+
+func oauthError(code, description string) gen.PostToken400JSONResponse {
+	return gen.PostToken400JSONResponse{Error: code, ErrorDescription: optional(description)}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
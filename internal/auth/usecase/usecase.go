@@ -2,33 +2,86 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/bogatyr285/auth-go/internal/auth/entity"
 	"github.com/bogatyr285/auth-go/internal/buildinfo"
 	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
-	"github.com/golang-jwt/jwt/v5"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
 )
 
 type UserRepository interface {
-	RegisterUser(ctx context.Context, u entity.UserAccount) error
+	// org, if set, gets the user as a member in the same transaction
+	RegisterUser(ctx context.Context, u entity.UserAccount, org *entity.Organization) (entity.UserAccount, error)
 	FindUserByEmail(ctx context.Context, username string) (entity.UserAccount, error)
+	UpdateLastLogin(ctx context.Context, username string, at time.Time) error
+	DeleteUser(ctx context.Context, username string) error
+	UpdatePassword(ctx context.Context, username, hash string) error
+	ChangePassword(ctx context.Context, username, hash string, keep int) error
+	PasswordHistory(ctx context.Context, username string, limit int) ([]string, error)
 }
 
 type CryptoPassword interface {
 	HashPassword(password string) ([]byte, error)
 	ComparePasswords(fromUser, fromDB string) bool
+	NeedsRehash(hash string) bool
+}
+
+type PasswordPolicy interface {
+	Check(password string) error
+}
+
+// TokenIssuer issues access tokens: JWTManager itself or PASETO issuer when configured.
+type TokenIssuer interface {
+	IssueToken(claims jwtmanager.Claims) (string, error)
+	VerifyToken(tokenString string) (jwtmanager.Claims, error)
 }
 
 type JWTManager interface {
-	IssueToken(userID string) (string, error)
-	VerifyToken(tokenString string) (*jwt.Token, error)
+	TokenIssuer
+	IssueOneTimeToken(subject, purpose string, ttl time.Duration) (string, string, error)
+	VerifyOneTimeToken(tokenString, purpose string) (string, string, error)
+	PublicKeys() []jwtmanager.JWK
 }
 
+const defaultImpersonationTTL = 15 * time.Minute
+
 type AuthUseCase struct {
 	ur UserRepository
 	cp CryptoPassword
 	jm JWTManager
 	bi buildinfo.BuildInfo
+
+	tokens TokenIssuer
+
+	log              *slog.Logger
+	impersonationTTL time.Duration
+	// lifetime of tokens issued by token exchange, 0 - exchange is disabled
+	tokenExchangeTTL time.Duration
+	exchangeActors   map[string]ExchangeGrant
+	passwordPolicy   PasswordPolicy
+	// how many last passwords can't be reused, including the current one
+	passwordHistory int
+	passwordMaxAge  time.Duration
+
+	orgs  OrganizationRepository
+	audit AuditRepository
+
+	webhookRepo WebhookRepository
+	webhooks    WebhookDispatcher
+
+	ml           MagicLinkRepository
+	notifier     Notifier
+	magicLinkURL string
+	magicLinkTTL time.Duration
+
+	devices               DeviceAuthorizationRepository
+	deviceVerificationURL string
+	deviceCodeTTL         time.Duration
+	devicePollInterval    time.Duration
+	deviceTokenTTL        time.Duration
 }
 
 func NewUseCase(
@@ -36,56 +89,174 @@ func NewUseCase(
 	cp CryptoPassword,
 	jm JWTManager,
 	bi buildinfo.BuildInfo,
+	opts ...Option,
 ) AuthUseCase {
-	return AuthUseCase{
+	u := AuthUseCase{
 		ur: ur,
 		cp: cp,
 		jm: jm,
 		bi: bi,
+
+		tokens:           jm,
+		log:              slog.Default(),
+		impersonationTTL: defaultImpersonationTTL,
 	}
+	for _, opt := range opts {
+		opt(&u)
+	}
+	return u
 }
 
 func (u AuthUseCase) PostLogin(ctx context.Context, request gen.PostLoginRequestObject) (gen.PostLoginResponseObject, error) {
 	user, err := u.ur.FindUserByEmail(ctx, request.Body.Username)
 	if err != nil {
+		u.record(ctx, entity.AuditEvent{Actor: request.Body.Username, Action: entity.AuditLogin, Result: entity.AuditResultFailure, Details: "unknown user"})
 		return gen.PostLogin500JSONResponse{}, nil
 	}
 
 	if !u.cp.ComparePasswords(user.Password, request.Body.Password) {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Result: entity.AuditResultFailure, Details: "wrong password"})
 		return gen.PostLogin401JSONResponse{Error: "unauth"}, nil
 	}
+	if !user.Active() {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Result: entity.AuditResultDenied, Details: "account is " + user.Status})
+		return gen.PostLogin403JSONResponse{Error: errorAccountDisabled}, nil
+	}
+	u.rehashPassword(ctx, user, request.Body.Password)
+
+	if u.passwordExpired(user) {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Result: entity.AuditResultDenied, Details: "password expired"})
+		return gen.PostLogin403JSONResponse{Error: errorPasswordExpired}, nil
+	}
+
+	var tenant string
+	if request.Body.Organization != nil && *request.Body.Organization != "" {
+		tenant = *request.Body.Organization
+		if err := u.checkMembership(ctx, tenant, user.Username); err != nil {
+			if errors.Is(err, errForbidden) {
+				u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Target: tenant, Result: entity.AuditResultDenied, Details: "not a member"})
+				return gen.PostLogin403JSONResponse{Error: "not a member of the organization"}, nil
+			}
+			return gen.PostLogin500JSONResponse{}, nil
+		}
+	}
 
-	token, err := u.jm.IssueToken(user.Username)
+	token, err := u.tokens.IssueToken(jwtmanager.NewClaims(user.Username).WithTenant(tenant).WithRole(user.Role))
 	if err != nil {
 		return gen.PostLogin500JSONResponse{}, err
 	}
 
+	u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditLogin, Target: tenant, Result: entity.AuditResultSuccess})
+	u.updateLastLogin(ctx, user.Username)
+
 	return gen.PostLogin200JSONResponse{
 		AccessToken: token,
 	}, nil
 }
 
 func (u AuthUseCase) PostRegister(ctx context.Context, request gen.PostRegisterRequestObject) (gen.PostRegisterResponseObject, error) {
-	hashedPassword, err := u.cp.HashPassword(request.Body.Password)
+	var org *entity.Organization
+	if request.Body.Organization != nil && *request.Body.Organization != "" {
+		if u.orgs == nil {
+			return gen.PostRegister400JSONResponse{Error: "organizations disabled"}, nil
+		}
+		o, err := u.orgs.FindOrganization(ctx, *request.Body.Organization)
+		if errors.Is(err, entity.ErrNotFound) {
+			return gen.PostRegister400JSONResponse{Error: "unknown organization"}, nil
+		}
+		if err != nil {
+			return gen.PostRegister500JSONResponse{}, nil
+		}
+		if o.Settings.RegistrationPolicy != entity.RegistrationOpen {
+			u.record(ctx, entity.AuditEvent{Actor: request.Body.Username, Action: entity.AuditRegister, Target: o.Slug, Result: entity.AuditResultDenied, Details: "registration is closed"})
+			return gen.PostRegister403JSONResponse{Error: "registration is closed"}, nil
+		}
+		org = &o
+	}
+
+	err := u.checkPasswordPolicy(request.Body.Password)
+	if errors.Is(err, entity.ErrWeakPassword) {
+		return gen.PostRegister400JSONResponse{Error: err.Error()}, nil
+	}
 	if err != nil {
+		u.log.ErrorContext(ctx, "password policy", slog.Any("err", err))
 		return gen.PostRegister500JSONResponse{}, nil
 	}
 
-	// TODO with New method
-	user := entity.UserAccount{
-		Username: request.Body.Username,
-		Password: string(hashedPassword),
+	hashedPassword, err := u.cp.HashPassword(request.Body.Password)
+	if err != nil {
+		return gen.PostRegister500JSONResponse{}, nil
 	}
 
-	err = u.ur.RegisterUser(ctx, user)
+	user := entity.NewUserAccount(request.Body.Username, string(hashedPassword))
+	user.Email = stringValue(request.Body.Email)
+	user.DisplayName = stringValue(request.Body.DisplayName)
+
+	registered, err := u.ur.RegisterUser(ctx, user, org)
+	if errors.Is(err, entity.ErrAlreadyExists) {
+		return gen.PostRegister409JSONResponse{Error: "username is taken"}, nil
+	}
 	if err != nil {
+		u.record(ctx, entity.AuditEvent{Actor: user.Username, Action: entity.AuditRegister, Result: entity.AuditResultFailure, Details: err.Error()})
 		return gen.PostRegister500JSONResponse{}, nil
 	}
+
+	var tenant string
+	if org != nil {
+		tenant = org.Slug
+	}
+
+	u.record(ctx, entity.AuditEvent{Actor: registered.Username, Action: entity.AuditRegister, Target: tenant, Result: entity.AuditResultSuccess})
+	u.publish(ctx, entity.EventUserRegistered, entity.UserEventData{Username: registered.Username, Organization: tenant})
+
 	return gen.PostRegister201JSONResponse{
-		Username: request.Body.Username,
+		Id:       registered.ID,
+		Username: registered.Username,
 	}, nil
 }
 
+// updateLastLogin is best effort, failing to remember the time doesn't fail the login.
+func (u AuthUseCase) updateLastLogin(ctx context.Context, username string) {
+	if err := u.ur.UpdateLastLogin(ctx, username, time.Now()); err != nil {
+		u.log.ErrorContext(ctx, "update last login", slog.Any("err", err))
+	}
+}
+
+// rehashPassword upgrades stored hash made with an outdated algorithm or parameters.
+// The plain password is only available here, so it's done on successful login.
+func (u AuthUseCase) rehashPassword(ctx context.Context, user entity.UserAccount, password string) {
+	if !u.cp.NeedsRehash(user.Password) {
+		return
+	}
+	hashed, err := u.cp.HashPassword(password)
+	if err != nil {
+		u.log.ErrorContext(ctx, "rehash password", slog.Any("err", err))
+		return
+	}
+	if err := u.ur.UpdatePassword(ctx, user.Username, string(hashed)); err != nil {
+		u.log.ErrorContext(ctx, "rehash password", slog.Any("err", err))
+	}
+}
+
+// checkMembership returns errForbidden if user doesn't belong to the organization (or it doesn't exist).
+func (u AuthUseCase) checkMembership(ctx context.Context, slug, username string) error {
+	if u.orgs == nil {
+		return errForbidden
+	}
+	org, err := u.orgs.FindOrganization(ctx, slug)
+	if errors.Is(err, entity.ErrNotFound) {
+		return errForbidden
+	}
+	if err != nil {
+		return err
+	}
+	_, err = u.orgs.FindMembership(ctx, org.ID, username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return errForbidden
+	}
+	return err
+}
+
 func (u AuthUseCase) GetBuildinfo(ctx context.Context, request gen.GetBuildinfoRequestObject) (gen.GetBuildinfoResponseObject, error) {
 	return gen.GetBuildinfo200JSONResponse{
 		Arch:       u.bi.Arch,
@@ -2,11 +2,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/auth/policy"
 	"github.com/bogatyr285/auth-go/internal/buildinfo"
 	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/middleware"
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -43,13 +48,638 @@ func TestPostLoginSuccessful(t *testing.T) {
 	mockJWT.AssertExpectations(t)
 }
 
+// Access токен выдает настроенный формат, а не JWTManager
+func TestPostLoginWithTokenIssuer(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockPaseto := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithTokenIssuer(mockPaseto))
+	setupMocksForSuccessfulLogin(mockUserRepo, mockCrypto, mockPaseto)
+
+	response, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostLogin200JSONResponse{AccessToken: "mockToken"}, response)
+
+	mockPaseto.AssertExpectations(t)
+	mockJWT.AssertNotCalled(t, "IssueToken", mock.Anything)
+}
+
+// Тестируем обмен magic-link токена на access токен
+func TestGetLoginMagicVerify(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockLinks := new(MockMagicLinkRepository)
+	mockWebhooks := new(MockWebhookDispatcher)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{},
+		WithMagicLink(mockLinks, nil, "http://localhost/login/magic/verify", time.Minute),
+		WithWebhooks(nil, mockWebhooks))
+
+	mockJWT.On("VerifyOneTimeToken", "linkToken", magicLinkPurpose).Return("testuser", "link-id", nil)
+	mockLinks.On("ConsumeMagicLink", mock.Anything, "link-id").Return(entity.MagicLink{ID: "link-id", Username: "testuser"}, nil).Once()
+	mockLinks.On("ConsumeMagicLink", mock.Anything, "link-id").Return(entity.MagicLink{}, entity.ErrNotFound)
+	mockLinks.On("MarkUserVerified", mock.Anything, "testuser", mock.Anything).Return(true, nil).Once()
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser")).Return("mockToken", nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{Username: "testuser", Role: entity.RoleUser, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("UpdateLastLogin", mock.Anything, "testuser", mock.Anything).Return(nil).Twice()
+	mockWebhooks.On("Enqueue", mock.Anything, entity.EventUserVerified, entity.UserEventData{Username: "testuser"}).Return(nil).Once()
+
+	request := gen.GetLoginMagicVerifyRequestObject{
+		Params: gen.GetLoginMagicVerifyParams{Token: "linkToken"},
+	}
+
+	response, err := authUseCase.GetLoginMagicVerify(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, gen.GetLoginMagicVerify200JSONResponse{AccessToken: "mockToken"}, response)
+
+	// повторно ссылкой воспользоваться нельзя
+	response, err = authUseCase.GetLoginMagicVerify(context.Background(), request)
+	require.NoError(t, err)
+	assert.IsType(t, gen.GetLoginMagicVerify401JSONResponse{}, response)
+
+	// следующий вход по новой ссылке уже не публикует user.verified
+	mockJWT.On("VerifyOneTimeToken", "nextLinkToken", magicLinkPurpose).Return("testuser", "next-link-id", nil)
+	mockLinks.On("ConsumeMagicLink", mock.Anything, "next-link-id").Return(entity.MagicLink{ID: "next-link-id", Username: "testuser"}, nil).Once()
+	mockLinks.On("MarkUserVerified", mock.Anything, "testuser", mock.Anything).Return(false, nil).Once()
+	response, err = authUseCase.GetLoginMagicVerify(context.Background(), gen.GetLoginMagicVerifyRequestObject{
+		Params: gen.GetLoginMagicVerifyParams{Token: "nextLinkToken"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.GetLoginMagicVerify200JSONResponse{AccessToken: "mockToken"}, response)
+
+	// аккаунт отключили после отправки ссылки: адрес не подтверждается
+	mockJWT.On("VerifyOneTimeToken", "frozenLinkToken", magicLinkPurpose).Return("frozen", "frozen-link-id", nil)
+	mockLinks.On("ConsumeMagicLink", mock.Anything, "frozen-link-id").Return(entity.MagicLink{ID: "frozen-link-id", Username: "frozen"}, nil).Once()
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "frozen").Return(entity.UserAccount{Username: "frozen", Role: entity.RoleUser, Status: entity.UserStatusDisabled}, nil)
+	response, err = authUseCase.GetLoginMagicVerify(context.Background(), gen.GetLoginMagicVerifyRequestObject{
+		Params: gen.GetLoginMagicVerifyParams{Token: "frozenLinkToken"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.GetLoginMagicVerify403JSONResponse{Error: errorAccountDisabled}, response)
+	mockLinks.AssertNotCalled(t, "MarkUserVerified", mock.Anything, "frozen", mock.Anything)
+
+	mockLinks.AssertExpectations(t)
+	mockJWT.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockWebhooks.AssertExpectations(t)
+}
+
+// Тестируем выдачу токена имперсонации: только админ и только по обычному токену
+func TestPostAdminImpersonate(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithImpersonationTTL(time.Minute))
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "admin").Return(entity.UserAccount{Username: "admin", Role: entity.RoleAdmin, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "exadmin").Return(entity.UserAccount{Username: "exadmin", Role: entity.RoleAdmin, Status: entity.UserStatusDisabled}, nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "support").Return(entity.UserAccount{Username: "support", Role: entity.RoleUser, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{Username: "testuser", Role: entity.RoleUser, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "frozen").Return(entity.UserAccount{Username: "frozen", Role: entity.RoleUser, Status: entity.UserStatusDisabled}, nil)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser").WithActor("admin").WithRole(entity.RoleUser).WithTTL(time.Minute)).Return("impersonationToken", nil)
+
+	request := gen.PostAdminImpersonateRequestObject{
+		Body: &gen.PostAdminImpersonateJSONRequestBody{
+			Username: "testuser",
+			Reason:   "TICKET-1",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected gen.PostAdminImpersonateResponseObject
+	}{
+		{
+			name: "Admin",
+			ctx:  middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "admin"}),
+			expected: gen.PostAdminImpersonate200JSONResponse{
+				AccessToken: "impersonationToken",
+				ExpiresIn:   60,
+			},
+		},
+		{
+			name:     "Anonymous",
+			ctx:      context.Background(),
+			expected: gen.PostAdminImpersonate401JSONResponse{Error: "unauth"},
+		},
+		{
+			name:     "Not an admin",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "support"}),
+			expected: gen.PostAdminImpersonate403JSONResponse{Error: "forbidden"},
+		},
+		{
+			name:     "Already impersonating",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "testuser", Actor: "admin"}),
+			expected: gen.PostAdminImpersonate403JSONResponse{Error: "forbidden"},
+		},
+		{
+			name:     "Disabled admin",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "exadmin"}),
+			expected: gen.PostAdminImpersonate403JSONResponse{Error: "forbidden"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := authUseCase.PostAdminImpersonate(tt.ctx, request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+
+	// отключенный аккаунт нельзя использовать и через имперсонацию
+	response, err := authUseCase.PostAdminImpersonate(middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "admin"}),
+		gen.PostAdminImpersonateRequestObject{Body: &gen.PostAdminImpersonateJSONRequestBody{Username: "frozen", Reason: "TICKET-2"}})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostAdminImpersonate403JSONResponse{Error: errorAccountDisabled}, response)
+}
+
+// Обмен токена: audience и scope только сужаются, вызывающий сервис попадает в "act"
+func TestPostTokenExchange(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	actors := map[string]ExchangeGrant{"gateway": {Audiences: []string{"billing", "reports"}, Scopes: []string{"read"}}}
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithTokenExchange(5*time.Minute, actors))
+
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Hour))
+	userClaims := jwtmanager.NewClaims("testuser").WithRole(entity.RoleUser).WithAudience("gateway", "billing").WithScopes("read", "write")
+	userClaims.ExpiresAt = expiresAt
+	unscopedClaims := jwtmanager.NewClaims("testuser").WithRole(entity.RoleUser)
+	unscopedClaims.ExpiresAt = expiresAt
+	serviceClaims := jwtmanager.NewClaims("gateway")
+	serviceClaims.ExpiresAt = expiresAt
+	unknownServiceClaims := jwtmanager.NewClaims("reports")
+	unknownServiceClaims.ExpiresAt = expiresAt
+
+	frozenClaims := jwtmanager.NewClaims("frozen").WithAudience("billing").WithScopes("read")
+	frozenClaims.ExpiresAt = expiresAt
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{Username: "testuser", Role: entity.RoleUser, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "frozen").Return(entity.UserAccount{Username: "frozen", Role: entity.RoleUser, Status: entity.UserStatusDisabled}, nil)
+	mockJWT.On("VerifyToken", "userToken").Return(userClaims, nil)
+	mockJWT.On("VerifyToken", "frozenToken").Return(frozenClaims, nil)
+	mockJWT.On("VerifyToken", "unscopedToken").Return(unscopedClaims, nil)
+	mockJWT.On("VerifyToken", "serviceToken").Return(serviceClaims, nil)
+	mockJWT.On("VerifyToken", "unknownServiceToken").Return(unknownServiceClaims, nil)
+	mockJWT.On("VerifyToken", "badToken").Return(jwtmanager.Claims{}, jwtmanager.ErrValidation)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser").
+		WithRole(entity.RoleUser).
+		WithAudience("billing").
+		WithScopes("read").
+		WithDelegation("gateway", nil).
+		WithTTL(5*time.Minute)).Return("exchangedToken", nil)
+
+	exchange := func(subjectToken, actorToken, audience, scope string) gen.PostTokenRequestObject {
+		body := &gen.PostTokenFormdataRequestBody{
+			GrantType:        grantTypeTokenExchange,
+			SubjectToken:     &subjectToken,
+			SubjectTokenType: optional(tokenTypeAccessToken),
+			Audience:         &audience,
+			Scope:            &scope,
+		}
+		if actorToken != "" {
+			body.ActorToken = &actorToken
+			body.ActorTokenType = optional(tokenTypeAccessToken)
+		}
+		return gen.PostTokenRequestObject{Body: body}
+	}
+	issued := gen.PostToken200JSONResponse{
+		AccessToken:     "exchangedToken",
+		IssuedTokenType: optional(tokenTypeAccessToken),
+		TokenType:       "Bearer",
+		ExpiresIn:       300,
+		Scope:           optional("read"),
+	}
+	service := middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "gateway"})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		request  gen.PostTokenRequestObject
+		expected gen.PostTokenResponseObject
+	}{
+		{
+			name:     "Actor token",
+			ctx:      context.Background(),
+			request:  exchange("userToken", "serviceToken", "billing", "read"),
+			expected: issued,
+		},
+		{
+			name:     "Bearer authenticated actor",
+			ctx:      service,
+			request:  exchange("userToken", "", "billing", "read"),
+			expected: issued,
+		},
+		{
+			name:     "No actor",
+			ctx:      context.Background(),
+			request:  exchange("userToken", "", "billing", "read"),
+			expected: oauthError("invalid_request", "actor_token or Bearer authentication is required"),
+		},
+		{
+			name:     "Invalid subject token",
+			ctx:      service,
+			request:  exchange("badToken", "", "billing", "read"),
+			expected: oauthError("invalid_grant", "invalid subject_token"),
+		},
+		{
+			name:     "Wider audience",
+			ctx:      service,
+			request:  exchange("userToken", "", "reports", "read"),
+			expected: oauthError("invalid_target", "audience is not allowed for subject_token"),
+		},
+		{
+			name:     "Wider scope",
+			ctx:      service,
+			request:  exchange("userToken", "", "billing", "admin"),
+			expected: oauthError("invalid_scope", "scope is not allowed for subject_token"),
+		},
+		{
+			name:     "Defaults to what both subject and actor allow",
+			ctx:      service,
+			request:  exchange("userToken", "", "", ""),
+			expected: issued,
+		},
+		{
+			name:     "Audience of subject token the actor may not request",
+			ctx:      service,
+			request:  exchange("userToken", "", "gateway", "read"),
+			expected: oauthError("invalid_target", "audience is not allowed for subject_token"),
+		},
+		{
+			name:     "Scope of subject token the actor may not request",
+			ctx:      service,
+			request:  exchange("userToken", "", "billing", "write"),
+			expected: oauthError("invalid_scope", "scope is not allowed for subject_token"),
+		},
+		{
+			name:     "Unscoped subject token grants nothing",
+			ctx:      service,
+			request:  exchange("unscopedToken", "", "billing", "read"),
+			expected: oauthError("invalid_target", "audience is not allowed for subject_token"),
+		},
+		{
+			name:     "Disabled account",
+			ctx:      service,
+			request:  exchange("frozenToken", "", "billing", "read"),
+			expected: oauthError("invalid_grant", "account is disabled"),
+		},
+		{
+			name:     "Actor is not allowed",
+			ctx:      context.Background(),
+			request:  exchange("userToken", "unknownServiceToken", "billing", "read"),
+			expected: oauthError("unauthorized_client", "actor is not allowed to exchange tokens"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := authUseCase.PostToken(tt.ctx, tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+
+	// без настройки обмен выключен
+	response, err := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}).
+		PostToken(service, exchange("userToken", "", "billing", "read"))
+	require.NoError(t, err)
+	assert.Equal(t, oauthError("unsupported_grant_type", ""), response)
+}
+
+// Совпадение user_code с ожидающим запросом не ломает старт: код генерируется заново
+func TestPostDeviceCodeUserCodeCollision(t *testing.T) {
+	mockDevices := new(MockDeviceAuthorizationRepository)
+	authUseCase := NewUseCase(new(MockUserRepository), new(MockCryptoPassword), new(MockJWTManager), buildinfo.BuildInfo{},
+		WithDeviceAuthorization(mockDevices, "http://localhost/device", 10*time.Minute, 5*time.Second, time.Hour))
+	request := gen.PostDeviceCodeRequestObject{Body: &gen.PostDeviceCodeFormdataRequestBody{ClientId: "tv"}}
+
+	var userCodes []string
+	save := mockDevices.On("SaveDeviceAuthorization", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		userCodes = append(userCodes, args.Get(1).(entity.DeviceAuthorization).UserCode)
+	})
+	save.Return(entity.ErrAlreadyExists).Once()
+	mockDevices.On("SaveDeviceAuthorization", mock.Anything, mock.Anything).Return(nil).Once()
+
+	response, err := authUseCase.PostDeviceCode(context.Background(), request)
+	require.NoError(t, err)
+	issued, ok := response.(gen.PostDeviceCode200JSONResponse)
+	require.True(t, ok, "%T", response)
+	require.Len(t, userCodes, 1, "only the failed attempt is recorded")
+	assert.NotEqual(t, formatUserCode(userCodes[0]), issued.UserCode)
+
+	// попытки не бесконечны
+	mockDevices = new(MockDeviceAuthorizationRepository)
+	mockDevices.On("SaveDeviceAuthorization", mock.Anything, mock.Anything).Return(entity.ErrAlreadyExists)
+	authUseCase = NewUseCase(new(MockUserRepository), new(MockCryptoPassword), new(MockJWTManager), buildinfo.BuildInfo{},
+		WithDeviceAuthorization(mockDevices, "http://localhost/device", 10*time.Minute, 5*time.Second, time.Hour))
+	response, err = authUseCase.PostDeviceCode(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostDeviceCode500JSONResponse{Error: "server_error"}, response)
+	mockDevices.AssertNumberOfCalls(t, "SaveDeviceAuthorization", userCodeAttempts)
+}
+
+// Устройство опрашивает /token, пока пользователь не примет решение
+func TestPostTokenDeviceCode(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockDevices := new(MockDeviceAuthorizationRepository)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{},
+		WithDeviceAuthorization(mockDevices, "http://localhost/device", 10*time.Minute, 5*time.Second, time.Hour))
+
+	expiresAt := time.Now().Add(time.Minute)
+	justPolled := time.Now().Add(-time.Second)
+	poll := func(code string, d entity.DeviceAuthorization) {
+		d.DeviceCodeHash, d.ClientID, d.Interval = hashDeviceCode(code), "cli", 5*time.Second
+		if d.ExpiresAt.IsZero() {
+			d.ExpiresAt = expiresAt
+		}
+		mockDevices.On("PollDeviceAuthorization", mock.Anything, hashDeviceCode(code), mock.Anything).Return(d, nil)
+	}
+	poll("pending", entity.DeviceAuthorization{Status: entity.DeviceStatusPending})
+	poll("tooFast", entity.DeviceAuthorization{Status: entity.DeviceStatusPending, LastPolledAt: &justPolled})
+	poll("denied", entity.DeviceAuthorization{Status: entity.DeviceStatusDenied, Username: "testuser"})
+	poll("expired", entity.DeviceAuthorization{Status: entity.DeviceStatusApproved, Username: "testuser", ExpiresAt: time.Now().Add(-time.Second)})
+	poll("approved", entity.DeviceAuthorization{Status: entity.DeviceStatusApproved, Username: "testuser", Scope: "read"})
+	mockDevices.On("PollDeviceAuthorization", mock.Anything, hashDeviceCode("unknown"), mock.Anything).Return(entity.DeviceAuthorization{}, entity.ErrNotFound)
+	mockDevices.On("SlowDownDevicePolling", mock.Anything, hashDeviceCode("tooFast"), 10*time.Second).Return(nil)
+	mockDevices.On("ConsumeDeviceAuthorization", mock.Anything, hashDeviceCode("approved")).Return(nil)
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{Username: "testuser", Role: entity.RoleUser, Status: entity.UserStatusActive}, nil)
+	mockUserRepo.On("UpdateLastLogin", mock.Anything, "testuser", mock.Anything).Return(nil)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser").
+		WithRole(entity.RoleUser).
+		WithScopes("read").
+		WithClaim("client_id", "cli").
+		WithTTL(time.Hour)).Return("deviceToken", nil)
+
+	tests := []struct {
+		deviceCode string
+		clientID   string
+		expected   gen.PostTokenResponseObject
+	}{
+		{"pending", "cli", oauthError("authorization_pending", "")},
+		{"tooFast", "cli", oauthError("slow_down", "")},
+		{"denied", "cli", oauthError("access_denied", "")},
+		{"expired", "cli", oauthError("expired_token", "")},
+		{"unknown", "cli", oauthError("invalid_grant", "unknown device_code")},
+		{"approved", "tv", oauthError("invalid_grant", "device_code was issued to another client")},
+		{"approved", "cli", gen.PostToken200JSONResponse{AccessToken: "deviceToken", TokenType: "Bearer", ExpiresIn: 3600, Scope: optional("read")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.deviceCode+"/"+tt.clientID, func(t *testing.T) {
+			response, err := authUseCase.PostToken(context.Background(), gen.PostTokenRequestObject{
+				Body: &gen.PostTokenFormdataRequestBody{GrantType: grantTypeDeviceCode, DeviceCode: &tt.deviceCode, ClientId: &tt.clientID},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+
+	mockDevices.AssertExpectations(t)
+	mockJWT.AssertExpectations(t)
+}
+
+// Запрос устройства подтверждает залогиненный пользователь, но не под имперсонацией
+func TestPostDevice(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockDevices := new(MockDeviceAuthorizationRepository)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{},
+		WithDeviceAuthorization(mockDevices, "http://localhost/device", 10*time.Minute, 5*time.Second, time.Hour))
+
+	mockDevices.On("FindDeviceAuthorization", mock.Anything, "BDWPHQPK").Return(entity.DeviceAuthorization{UserCode: "BDWPHQPK", ClientID: "cli", Status: entity.DeviceStatusPending}, nil)
+	mockDevices.On("FindDeviceAuthorization", mock.Anything, "XXXXXXXX").Return(entity.DeviceAuthorization{}, entity.ErrNotFound)
+	mockDevices.On("DecideDeviceAuthorization", mock.Anything, "BDWPHQPK", entity.DeviceStatusApproved, "testuser", "acme").Return(nil).Once()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		userCode string
+		expected gen.PostDeviceResponseObject
+	}{
+		{
+			name:     "Approved",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "testuser", Tenant: "acme"}),
+			userCode: "bdwp-hqpk",
+			expected: gen.PostDevice204Response{},
+		},
+		{
+			name:     "Unknown code",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "testuser"}),
+			userCode: "XXXX-XXXX",
+			expected: gen.PostDevice404JSONResponse{Error: "unknown or expired code"},
+		},
+		{
+			name:     "Anonymous",
+			ctx:      context.Background(),
+			userCode: "BDWP-HQPK",
+			expected: gen.PostDevice401JSONResponse{Error: "unauth"},
+		},
+		{
+			name:     "Impersonation",
+			ctx:      middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "testuser", Actor: "admin"}),
+			userCode: "BDWP-HQPK",
+			expected: gen.PostDevice403JSONResponse{Error: "forbidden"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := authUseCase.PostDevice(tt.ctx, gen.PostDeviceRequestObject{
+				Body: &gen.PostDeviceJSONRequestBody{UserCode: tt.userCode, Approve: true},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+
+	mockDevices.AssertExpectations(t)
+}
+
+// Регистрация в организацию зависит от ее политики, а логин в организацию требует членства
+func TestOrganizationRegistrationAndLogin(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockOrgs := new(MockOrganizationRepository)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithOrganizations(mockOrgs))
+
+	openOrg := entity.Organization{ID: 1, Slug: "acme", Settings: entity.OrganizationSettings{RegistrationPolicy: entity.RegistrationOpen}}
+	closedOrg := entity.Organization{ID: 2, Slug: "corp", Settings: entity.OrganizationSettings{RegistrationPolicy: entity.RegistrationClosed}}
+	mockOrgs.On("FindOrganization", mock.Anything, "acme").Return(openOrg, nil)
+	mockOrgs.On("FindOrganization", mock.Anything, "corp").Return(closedOrg, nil)
+	mockOrgs.On("FindMembership", mock.Anything, int64(1), "testuser").Return(entity.Membership{OrgID: 1, Username: "testuser", Role: entity.OrgRoleMember}, nil)
+	mockOrgs.On("FindMembership", mock.Anything, int64(2), "testuser").Return(entity.Membership{}, entity.ErrNotFound)
+	mockCrypto.On("HashPassword", "password").Return([]byte("hashedpassword"), nil)
+	mockUserRepo.On("RegisterUser", mock.Anything, entity.NewUserAccount("testuser", "hashedpassword"), &openOrg).Return(entity.UserAccount{ID: 42, Username: "testuser"}, nil).Once()
+	mockUserRepo.On("RegisterUser", mock.Anything, entity.NewUserAccount("testuser", "hashedpassword"), &openOrg).Return(entity.UserAccount{}, entity.ErrAlreadyExists)
+	setupMocksForSuccessfulLogin(mockUserRepo, mockCrypto, mockJWT)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser").WithTenant("acme")).Return("acmeToken", nil)
+
+	acme, corp := "acme", "corp"
+
+	regResponse, err := authUseCase.PostRegister(context.Background(), gen.PostRegisterRequestObject{
+		Body: &gen.PostRegisterJSONRequestBody{Username: "testuser", Password: "password", Organization: &corp},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostRegister403JSONResponse{}, regResponse)
+
+	regResponse, err = authUseCase.PostRegister(context.Background(), gen.PostRegisterRequestObject{
+		Body: &gen.PostRegisterJSONRequestBody{Username: "testuser", Password: "password", Organization: &acme},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostRegister201JSONResponse{Id: 42, Username: "testuser"}, regResponse)
+
+	regResponse, err = authUseCase.PostRegister(context.Background(), gen.PostRegisterRequestObject{
+		Body: &gen.PostRegisterJSONRequestBody{Username: "testuser", Password: "password", Organization: &acme},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostRegister409JSONResponse{}, regResponse)
+
+	loginResponse, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password", Organization: &acme},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostLogin200JSONResponse{AccessToken: "acmeToken"}, loginResponse)
+
+	loginResponse, err = authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password", Organization: &corp},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostLogin403JSONResponse{}, loginResponse)
+
+	mockOrgs.AssertExpectations(t)
+}
+
+// Токен админа, полученный сервисом через обмен, не дает админских прав
+func TestAdminEndpointsRejectDelegatedTokens(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockOrgs := new(MockOrganizationRepository)
+	authUseCase := NewUseCase(mockUserRepo, new(MockCryptoPassword), new(MockJWTManager), buildinfo.BuildInfo{}, WithOrganizations(mockOrgs))
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "admin").Return(entity.UserAccount{Username: "admin", Role: entity.RoleAdmin, Status: entity.UserStatusActive}, nil)
+	mockOrgs.On("FindOrganization", mock.Anything, "acme").Return(entity.Organization{ID: 1, Slug: "acme"}, nil)
+	mockOrgs.On("FindMembership", mock.Anything, int64(1), "admin").Return(entity.Membership{OrgID: 1, Username: "admin", Role: entity.OrgRoleAdmin}, nil)
+
+	delegated := middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "admin", Actor: "gateway"})
+	orgResponse, err := authUseCase.PostOrgs(delegated, gen.PostOrgsRequestObject{Body: &gen.PostOrgsJSONRequestBody{Slug: "corp", Name: "Corp"}})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostOrgs403JSONResponse{Error: "forbidden"}, orgResponse)
+
+	scoped := middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "admin", Actor: "gateway", Tenant: "acme"})
+	slugResponse, err := authUseCase.GetOrgsSlug(scoped, gen.GetOrgsSlugRequestObject{Slug: "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, gen.GetOrgsSlug403JSONResponse{Error: "forbidden"}, slugResponse)
+
+	// тот же админ со своим токеном проходит
+	own := middleware.WithIdentity(context.Background(), middleware.Identity{Subject: "admin", Tenant: "acme"})
+	slugResponse, err = authUseCase.GetOrgsSlug(own, gen.GetOrgsSlugRequestObject{Slug: "acme"})
+	require.NoError(t, err)
+	assert.IsType(t, gen.GetOrgsSlug200JSONResponse{}, slugResponse)
+}
+
+// Неудачный логин попадает в журнал аудита
+func TestPostLoginAuditsFailure(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+	mockAudit := new(MockAuditRepository)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithAuditLog(mockAudit))
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{
+		Username: "testuser",
+		Password: "hashedpassword",
+	}, nil)
+	mockCrypto.On("ComparePasswords", "hashedpassword", "wrong").Return(false)
+	mockAudit.On("AppendAuditEvent", mock.Anything, mock.MatchedBy(func(e entity.AuditEvent) bool {
+		return e.Actor == "testuser" && e.Action == entity.AuditLogin && e.Result == entity.AuditResultFailure && !e.OccurredAt.IsZero()
+	})).Return(nil).Once()
+
+	response, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "wrong"},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostLogin401JSONResponse{}, response)
+
+	mockAudit.AssertExpectations(t)
+}
+
+// Неудачная регистрация попадает в аудит с именем пользователя из запроса
+func TestPostRegisterAuditsFailure(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockAudit := new(MockAuditRepository)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, new(MockJWTManager), buildinfo.BuildInfo{}, WithAuditLog(mockAudit))
+
+	mockCrypto.On("HashPassword", "password").Return([]byte("hashedpassword"), nil)
+	mockUserRepo.On("RegisterUser", mock.Anything, mock.Anything, (*entity.Organization)(nil)).Return(entity.UserAccount{}, errors.New("disk is full"))
+	mockAudit.On("AppendAuditEvent", mock.Anything, mock.MatchedBy(func(e entity.AuditEvent) bool {
+		return e.Actor == "testuser" && e.Action == entity.AuditRegister && e.Result == entity.AuditResultFailure
+	})).Return(nil).Once()
+
+	response, err := authUseCase.PostRegister(context.Background(), gen.PostRegisterRequestObject{
+		Body: &gen.PostRegisterJSONRequestBody{Username: "testuser", Password: "password"},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostRegister500JSONResponse{}, response)
+
+	mockAudit.AssertExpectations(t)
+}
+
+// Заблокированный пользователь не получает токен даже с верным паролем
+func TestPostLoginDisabledAccount(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{})
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{
+		Username: "testuser",
+		Password: "hashedpassword",
+		Status:   entity.UserStatusDisabled,
+	}, nil)
+	mockCrypto.On("ComparePasswords", "hashedpassword", "password").Return(true)
+
+	response, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostLogin403JSONResponse{Error: "account_disabled"}, response)
+	mockJWT.AssertNotCalled(t, "IssueToken", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "UpdateLastLogin", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // Мок для UserRepository
 type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) RegisterUser(ctx context.Context, u entity.UserAccount) error {
-	args := m.Called(ctx, u)
+func (m *MockUserRepository) RegisterUser(ctx context.Context, u entity.UserAccount, org *entity.Organization) (entity.UserAccount, error) {
+	args := m.Called(ctx, u, org)
+
+	return args.Get(0).(entity.UserAccount), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, username string, at time.Time) error {
+	args := m.Called(ctx, username, at)
 
 	return args.Error(0)
 }
@@ -60,6 +690,300 @@ func (m *MockUserRepository) FindUserByEmail(ctx context.Context, username strin
 	return args.Get(0).(entity.UserAccount), args.Error(1)
 }
 
+func (m *MockUserRepository) DeleteUser(ctx context.Context, username string) error {
+	args := m.Called(ctx, username)
+
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, username, hash string) error {
+	args := m.Called(ctx, username, hash)
+
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ChangePassword(ctx context.Context, username, hash string, keep int) error {
+	args := m.Called(ctx, username, hash, keep)
+
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) PasswordHistory(ctx context.Context, username string, limit int) ([]string, error) {
+	args := m.Called(ctx, username, limit)
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Мок для MagicLinkRepository
+type MockMagicLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockMagicLinkRepository) SaveMagicLink(ctx context.Context, l entity.MagicLink) error {
+	args := m.Called(ctx, l)
+
+	return args.Error(0)
+}
+
+func (m *MockMagicLinkRepository) ConsumeMagicLink(ctx context.Context, id string) (entity.MagicLink, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(entity.MagicLink), args.Error(1)
+}
+
+func (m *MockMagicLinkRepository) MarkUserVerified(ctx context.Context, username string, at time.Time) (bool, error) {
+	args := m.Called(ctx, username, at)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// Мок для WebhookDispatcher
+type MockWebhookDispatcher struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDispatcher) Enqueue(ctx context.Context, event string, data interface{}) error {
+	args := m.Called(ctx, event, data)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookDispatcher) Redeliver(ctx context.Context, deliveryID int64) (entity.WebhookDelivery, error) {
+	args := m.Called(ctx, deliveryID)
+
+	return args.Get(0).(entity.WebhookDelivery), args.Error(1)
+}
+
+// Мок для DeviceAuthorizationRepository
+type MockDeviceAuthorizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceAuthorizationRepository) SaveDeviceAuthorization(ctx context.Context, d entity.DeviceAuthorization) error {
+	args := m.Called(ctx, d)
+
+	return args.Error(0)
+}
+
+func (m *MockDeviceAuthorizationRepository) FindDeviceAuthorization(ctx context.Context, userCode string) (entity.DeviceAuthorization, error) {
+	args := m.Called(ctx, userCode)
+
+	return args.Get(0).(entity.DeviceAuthorization), args.Error(1)
+}
+
+func (m *MockDeviceAuthorizationRepository) DecideDeviceAuthorization(ctx context.Context, userCode, status, username, tenant string) error {
+	args := m.Called(ctx, userCode, status, username, tenant)
+
+	return args.Error(0)
+}
+
+func (m *MockDeviceAuthorizationRepository) PollDeviceAuthorization(ctx context.Context, deviceCodeHash string, now time.Time) (entity.DeviceAuthorization, error) {
+	args := m.Called(ctx, deviceCodeHash, now)
+
+	return args.Get(0).(entity.DeviceAuthorization), args.Error(1)
+}
+
+func (m *MockDeviceAuthorizationRepository) SlowDownDevicePolling(ctx context.Context, deviceCodeHash string, interval time.Duration) error {
+	args := m.Called(ctx, deviceCodeHash, interval)
+
+	return args.Error(0)
+}
+
+func (m *MockDeviceAuthorizationRepository) ConsumeDeviceAuthorization(ctx context.Context, deviceCodeHash string) error {
+	args := m.Called(ctx, deviceCodeHash)
+
+	return args.Error(0)
+}
+
+// Мок для OrganizationRepository
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) CreateOrganization(ctx context.Context, o entity.Organization) (entity.Organization, error) {
+	args := m.Called(ctx, o)
+
+	return args.Get(0).(entity.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindOrganization(ctx context.Context, slug string) (entity.Organization, error) {
+	args := m.Called(ctx, slug)
+
+	return args.Get(0).(entity.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateOrganizationSettings(ctx context.Context, orgID int64, settings entity.OrganizationSettings) error {
+	args := m.Called(ctx, orgID, settings)
+
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) SaveMembership(ctx context.Context, mb entity.Membership) error {
+	args := m.Called(ctx, mb)
+
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) FindMembership(ctx context.Context, orgID int64, username string) (entity.Membership, error) {
+	args := m.Called(ctx, orgID, username)
+
+	return args.Get(0).(entity.Membership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListMembers(ctx context.Context, orgID int64) ([]entity.Membership, error) {
+	args := m.Called(ctx, orgID)
+
+	return args.Get(0).([]entity.Membership), args.Error(1)
+}
+
+// Мок для AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) AppendAuditEvent(ctx context.Context, e entity.AuditEvent) error {
+	args := m.Called(ctx, e)
+
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListAuditEvents(ctx context.Context, f entity.AuditFilter) ([]entity.AuditEvent, error) {
+	args := m.Called(ctx, f)
+
+	return args.Get(0).([]entity.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) ExportAuditEvents(ctx context.Context, f entity.AuditFilter, fn func(entity.AuditEvent) error) error {
+	args := m.Called(ctx, f, fn)
+
+	return args.Error(0)
+}
+
+// Хеш устаревшего алгоритма заменяется при успешном логине
+func TestPostLoginRehashesOutdatedPassword(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{})
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{
+		Username: "testuser",
+		Password: "$2a$10$legacy",
+		Status:   entity.UserStatusActive,
+	}, nil)
+	mockCrypto.On("ComparePasswords", "$2a$10$legacy", "password").Return(true)
+	mockCrypto.On("NeedsRehash", "$2a$10$legacy").Return(true)
+	mockCrypto.On("HashPassword", "password").Return([]byte("$argon2id$new"), nil)
+	mockUserRepo.On("UpdatePassword", mock.Anything, "testuser", "$argon2id$new").Return(nil).Once()
+	mockUserRepo.On("UpdateLastLogin", mock.Anything, "testuser", mock.Anything).Return(nil)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser")).Return("mockToken", nil)
+
+	response, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostLogin200JSONResponse{AccessToken: "mockToken"}, response)
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+// С просроченным паролем логин невозможен, но пароль можно сменить
+func TestExpiredPasswordChange(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{}, WithPasswordRotation(3, 24*time.Hour))
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{
+		Username:          "testuser",
+		Password:          "hash3",
+		Status:            entity.UserStatusActive,
+		PasswordChangedAt: time.Now().Add(-48 * time.Hour),
+	}, nil)
+	mockCrypto.On("ComparePasswords", "hash3", "password3").Return(true)
+	mockCrypto.On("NeedsRehash", "hash3").Return(false)
+
+	loginResponse, err := authUseCase.PostLogin(context.Background(), gen.PostLoginRequestObject{
+		Body: &gen.PostLoginJSONRequestBody{Username: "testuser", Password: "password3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostLogin403JSONResponse{Error: "password_expired"}, loginResponse)
+	mockJWT.AssertNotCalled(t, "IssueToken", mock.Anything)
+
+	// последние 3 пароля: текущий и два из истории
+	mockUserRepo.On("PasswordHistory", mock.Anything, "testuser", 2).Return([]string{"hash2", "hash1"}, nil)
+	mockCrypto.On("ComparePasswords", "hash1", "password1").Return(true)
+	mockCrypto.On("ComparePasswords", mock.Anything, mock.Anything).Return(false)
+	mockCrypto.On("HashPassword", "password4").Return([]byte("hash4"), nil)
+	mockUserRepo.On("ChangePassword", mock.Anything, "testuser", "hash4", 2).Return(nil).Once()
+
+	changeRequest := func(newPassword string) gen.PostPasswordChangeRequestObject {
+		return gen.PostPasswordChangeRequestObject{Body: &gen.PostPasswordChangeJSONRequestBody{
+			Username: "testuser", CurrentPassword: "password3", NewPassword: newPassword,
+		}}
+	}
+
+	response, err := authUseCase.PostPasswordChange(context.Background(), changeRequest("password1"))
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostPasswordChange400JSONResponse{Error: errPasswordReused.Error()}, response)
+
+	response, err = authUseCase.PostPasswordChange(context.Background(), changeRequest("password4"))
+	require.NoError(t, err)
+	assert.IsType(t, gen.PostPasswordChange204Response{}, response)
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Пароль отключенного аккаунта сменить нельзя
+func TestPasswordChangeDisabledAccount(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{})
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "frozen").Return(entity.UserAccount{Username: "frozen", Password: "hash1", Status: entity.UserStatusDisabled}, nil)
+	mockCrypto.On("ComparePasswords", "hash1", "password1").Return(true)
+
+	response, err := authUseCase.PostPasswordChange(context.Background(), gen.PostPasswordChangeRequestObject{Body: &gen.PostPasswordChangeJSONRequestBody{
+		Username: "frozen", CurrentPassword: "password1", NewPassword: "password2",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, gen.PostPasswordChange403JSONResponse{Error: errorAccountDisabled}, response)
+
+	mockCrypto.AssertNotCalled(t, "HashPassword", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Пароль из утечек отклоняется при регистрации
+func TestPostRegisterRejectsWeakPassword(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockCrypto := new(MockCryptoPassword)
+	mockJWT := new(MockJWTManager)
+
+	authUseCase := NewUseCase(mockUserRepo, mockCrypto, mockJWT, buildinfo.BuildInfo{},
+		WithPasswordPolicy(policy.PasswordPolicy{MinLength: 8, Breaches: breachedPasswords{"password": 100}}))
+
+	for _, password := range []string{"short", "password"} {
+		response, err := authUseCase.PostRegister(context.Background(), gen.PostRegisterRequestObject{
+			Body: &gen.PostRegisterJSONRequestBody{Username: "testuser", Password: password},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, gen.PostRegister400JSONResponse{}, response, password)
+	}
+
+	mockCrypto.AssertNotCalled(t, "HashPassword", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "RegisterUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+type breachedPasswords map[string]int
+
+func (b breachedPasswords) Count(password string) (int, error) {
+	return b[password], nil
+}
+
 // Мок для CryptoPassword
 type MockCryptoPassword struct {
 	mock.Mock
@@ -77,21 +1001,45 @@ func (m *MockCryptoPassword) ComparePasswords(fromUser, fromDB string) bool {
 	return args.Bool(0)
 }
 
+func (m *MockCryptoPassword) NeedsRehash(hash string) bool {
+	args := m.Called(hash)
+
+	return args.Bool(0)
+}
+
 // Мок для JWTManager
 type MockJWTManager struct {
 	mock.Mock
 }
 
-func (m *MockJWTManager) IssueToken(userID string) (string, error) {
-	args := m.Called(userID)
+func (m *MockJWTManager) IssueToken(claims jwtmanager.Claims) (string, error) {
+	args := m.Called(claims)
 
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockJWTManager) VerifyToken(tokenString string) (*jwt.Token, error) {
+func (m *MockJWTManager) VerifyToken(tokenString string) (jwtmanager.Claims, error) {
 	args := m.Called(tokenString)
 
-	return args.Get(0).(*jwt.Token), args.Error(1)
+	return args.Get(0).(jwtmanager.Claims), args.Error(1)
+}
+
+func (m *MockJWTManager) IssueOneTimeToken(subject, purpose string, ttl time.Duration) (string, string, error) {
+	args := m.Called(subject, purpose, ttl)
+
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockJWTManager) VerifyOneTimeToken(tokenString, purpose string) (string, string, error) {
+	args := m.Called(tokenString, purpose)
+
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockJWTManager) PublicKeys() []jwtmanager.JWK {
+	args := m.Called()
+
+	return args.Get(0).([]jwtmanager.JWK)
 }
 
 // Приватная функция для настройки моков
@@ -103,7 +1051,10 @@ func setupMocksForSuccessfulLogin(
 	mockUserRepo.On("FindUserByEmail", mock.Anything, "testuser").Return(entity.UserAccount{
 		Username: "testuser",
 		Password: "hashedpassword",
+		Status:   entity.UserStatusActive,
 	}, nil)
+	mockUserRepo.On("UpdateLastLogin", mock.Anything, "testuser", mock.Anything).Return(nil)
 	mockCrypto.On("ComparePasswords", "hashedpassword", "password").Return(true)
-	mockJWT.On("IssueToken", "testuser").Return("mockToken", nil)
+	mockCrypto.On("NeedsRehash", "hashedpassword").Return(false)
+	mockJWT.On("IssueToken", jwtmanager.NewClaims("testuser")).Return("mockToken", nil)
 }
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/bogatyr285/auth-go/internal/gateway/http/gen"
+	"github.com/bogatyr285/auth-go/internal/webhook"
+)
+
+const (
+	defaultDeliveriesLimit = 100
+	maxDeliveriesLimit     = 1000
+)
+
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, sub entity.WebhookSubscription) (entity.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]entity.WebhookDelivery, error)
+}
+
+// WebhookDispatcher queues events for subscribers, actual sending happens in background.
+type WebhookDispatcher interface {
+	Enqueue(ctx context.Context, event string, data interface{}) error
+	Redeliver(ctx context.Context, deliveryID int64) (entity.WebhookDelivery, error)
+}
+
+// publish notifies webhook subscribers. Like audit, failures don't affect the action itself.
+func (u AuthUseCase) publish(ctx context.Context, event string, data entity.UserEventData) {
+	if u.webhooks == nil {
+		return
+	}
+	if err := u.webhooks.Enqueue(ctx, event, data); err != nil {
+		u.log.ErrorContext(ctx, "webhook enqueue", slog.String("event", event), slog.Any("err", err))
+	}
+}
+
+func (u AuthUseCase) PostAdminWebhooks(ctx context.Context, request gen.PostAdminWebhooksRequestObject) (gen.PostAdminWebhooksResponseObject, error) {
+	if u.webhookRepo == nil {
+		return gen.PostAdminWebhooks403JSONResponse{Error: "webhooks disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PostAdminWebhooks401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PostAdminWebhooks403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.PostAdminWebhooks500JSONResponse{}, nil
+	}
+
+	if !validWebhookURL(request.Body.Url) {
+		return gen.PostAdminWebhooks400JSONResponse{Error: "url must be absolute http(s) url"}, nil
+	}
+	if len(request.Body.Events) == 0 {
+		return gen.PostAdminWebhooks400JSONResponse{Error: "events are required"}, nil
+	}
+	events := make([]string, 0, len(request.Body.Events))
+	for _, e := range request.Body.Events {
+		if !validWebhookEvent(string(e)) {
+			return gen.PostAdminWebhooks400JSONResponse{Error: "unknown event " + string(e)}, nil
+		}
+		events = append(events, string(e))
+	}
+
+	var secret string
+	if request.Body.Secret != nil && *request.Body.Secret != "" {
+		secret = *request.Body.Secret
+	} else {
+		var err error
+		if secret, err = webhook.NewSecret(); err != nil {
+			return gen.PostAdminWebhooks500JSONResponse{}, nil
+		}
+	}
+
+	sub, err := u.webhookRepo.CreateWebhookSubscription(ctx, entity.WebhookSubscription{
+		URL:    request.Body.Url,
+		Secret: secret,
+		Events: events,
+	})
+	if err != nil {
+		return gen.PostAdminWebhooks500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditWebhookCreate, Target: sub.URL, Result: entity.AuditResultSuccess})
+
+	resp := toGenWebhookSubscription(sub)
+	resp.Secret = &sub.Secret
+	return gen.PostAdminWebhooks201JSONResponse(resp), nil
+}
+
+func (u AuthUseCase) GetAdminWebhooks(ctx context.Context, request gen.GetAdminWebhooksRequestObject) (gen.GetAdminWebhooksResponseObject, error) {
+	if u.webhookRepo == nil {
+		return gen.GetAdminWebhooks403JSONResponse{Error: "webhooks disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.GetAdminWebhooks401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.GetAdminWebhooks403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.GetAdminWebhooks500JSONResponse{}, nil
+	}
+
+	subs, err := u.webhookRepo.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return gen.GetAdminWebhooks500JSONResponse{}, nil
+	}
+
+	resp := make(gen.GetAdminWebhooks200JSONResponse, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, toGenWebhookSubscription(sub))
+	}
+	return resp, nil
+}
+
+func (u AuthUseCase) DeleteAdminWebhooksId(ctx context.Context, request gen.DeleteAdminWebhooksIdRequestObject) (gen.DeleteAdminWebhooksIdResponseObject, error) {
+	if u.webhookRepo == nil {
+		return gen.DeleteAdminWebhooksId403JSONResponse{Error: "webhooks disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.DeleteAdminWebhooksId401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.DeleteAdminWebhooksId403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.DeleteAdminWebhooksId500JSONResponse{}, nil
+	}
+
+	sub, err := u.webhookRepo.FindWebhookSubscription(ctx, request.Id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.DeleteAdminWebhooksId404JSONResponse{Error: "subscription not found"}, nil
+	}
+	if err != nil {
+		return gen.DeleteAdminWebhooksId500JSONResponse{}, nil
+	}
+	if err := u.webhookRepo.DeleteWebhookSubscription(ctx, sub.ID); err != nil {
+		return gen.DeleteAdminWebhooksId500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditWebhookDelete, Target: sub.URL, Result: entity.AuditResultSuccess})
+
+	return gen.DeleteAdminWebhooksId204Response{}, nil
+}
+
+func (u AuthUseCase) GetAdminWebhooksIdDeliveries(ctx context.Context, request gen.GetAdminWebhooksIdDeliveriesRequestObject) (gen.GetAdminWebhooksIdDeliveriesResponseObject, error) {
+	if u.webhookRepo == nil {
+		return gen.GetAdminWebhooksIdDeliveries403JSONResponse{Error: "webhooks disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.GetAdminWebhooksIdDeliveries401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.GetAdminWebhooksIdDeliveries403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.GetAdminWebhooksIdDeliveries500JSONResponse{}, nil
+	}
+
+	limit := defaultDeliveriesLimit
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+	if limit < 1 || limit > maxDeliveriesLimit {
+		return gen.GetAdminWebhooksIdDeliveries400JSONResponse{Error: "limit is out of range"}, nil
+	}
+
+	_, err := u.webhookRepo.FindWebhookSubscription(ctx, request.Id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.GetAdminWebhooksIdDeliveries404JSONResponse{Error: "subscription not found"}, nil
+	}
+	if err != nil {
+		return gen.GetAdminWebhooksIdDeliveries500JSONResponse{}, nil
+	}
+
+	deliveries, err := u.webhookRepo.ListWebhookDeliveries(ctx, request.Id, limit)
+	if err != nil {
+		return gen.GetAdminWebhooksIdDeliveries500JSONResponse{}, nil
+	}
+
+	resp := make(gen.GetAdminWebhooksIdDeliveries200JSONResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, toGenWebhookDelivery(d))
+	}
+	return resp, nil
+}
+
+func (u AuthUseCase) PostAdminWebhooksDeliveriesIdRedeliver(ctx context.Context, request gen.PostAdminWebhooksDeliveriesIdRedeliverRequestObject) (gen.PostAdminWebhooksDeliveriesIdRedeliverResponseObject, error) {
+	if u.webhooks == nil {
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver403JSONResponse{Error: "webhooks disabled"}, nil
+	}
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver500JSONResponse{}, nil
+	}
+
+	d, err := u.webhooks.Redeliver(ctx, request.Id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver404JSONResponse{Error: "delivery not found"}, nil
+	}
+	if err != nil {
+		return gen.PostAdminWebhooksDeliveriesIdRedeliver500JSONResponse{}, nil
+	}
+
+	return gen.PostAdminWebhooksDeliveriesIdRedeliver202JSONResponse(toGenWebhookDelivery(d)), nil
+}
+
+func (u AuthUseCase) DeleteAdminUsersUsername(ctx context.Context, request gen.DeleteAdminUsersUsernameRequestObject) (gen.DeleteAdminUsersUsernameResponseObject, error) {
+	switch err := u.requireGlobalAdmin(ctx); {
+	case errors.Is(err, errUnauthenticated):
+		return gen.DeleteAdminUsersUsername401JSONResponse{Error: "unauth"}, nil
+	case errors.Is(err, errForbidden):
+		return gen.DeleteAdminUsersUsername403JSONResponse{Error: "forbidden"}, nil
+	case err != nil:
+		return gen.DeleteAdminUsersUsername500JSONResponse{}, nil
+	}
+
+	err := u.ur.DeleteUser(ctx, request.Username)
+	if errors.Is(err, entity.ErrNotFound) {
+		return gen.DeleteAdminUsersUsername404JSONResponse{Error: "user not found"}, nil
+	}
+	if err != nil {
+		u.record(ctx, entity.AuditEvent{Action: entity.AuditUserDelete, Target: request.Username, Result: entity.AuditResultFailure, Details: err.Error()})
+		return gen.DeleteAdminUsersUsername500JSONResponse{}, nil
+	}
+
+	u.record(ctx, entity.AuditEvent{Action: entity.AuditUserDelete, Target: request.Username, Result: entity.AuditResultSuccess})
+	u.publish(ctx, entity.EventUserDeleted, entity.UserEventData{Username: request.Username})
+
+	return gen.DeleteAdminUsersUsername204Response{}, nil
+}
+
+func validWebhookURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func validWebhookEvent(e string) bool {
+	switch e {
+	case entity.EventUserRegistered, entity.EventUserVerified, entity.EventUserPasswordChanged, entity.EventUserDeleted, "*":
+		return true
+	}
+	return false
+}
+
+func toGenWebhookSubscription(sub entity.WebhookSubscription) gen.WebhookSubscription {
+	return gen.WebhookSubscription{
+		Id:        sub.ID,
+		Url:       sub.URL,
+		Events:    sub.Events,
+		CreatedAt: sub.CreatedAt,
+	}
+}
+
+func toGenWebhookDelivery(d entity.WebhookDelivery) gen.WebhookDelivery {
+	return gen.WebhookDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		Event:          d.Event,
+		Status:         gen.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		ResponseCode:   d.ResponseCode,
+		Error:          d.Error,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
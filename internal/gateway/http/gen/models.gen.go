@@ -1,8 +1,67 @@
 // Package gen provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package gen
 
+import (
+	"time"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CreateWebhookRequestEvents.
+const (
+	Asterisk            CreateWebhookRequestEvents = "*"
+	UserDeleted         CreateWebhookRequestEvents = "user.deleted"
+	UserPasswordChanged CreateWebhookRequestEvents = "user.password_changed"
+	UserRegistered      CreateWebhookRequestEvents = "user.registered"
+	UserVerified        CreateWebhookRequestEvents = "user.verified"
+)
+
+// Defines values for OrgRole.
+const (
+	Admin  OrgRole = "admin"
+	Member OrgRole = "member"
+)
+
+// Defines values for RegistrationPolicy.
+const (
+	Closed RegistrationPolicy = "closed"
+	Open   RegistrationPolicy = "open"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action string `json:"action"`
+
+	// Actor Who performed the action, empty for anonymous requests
+	Actor   string `json:"actor"`
+	Details string `json:"details"`
+
+	// Hash SHA-256 over prevHash and the event fields
+	Hash       string    `json:"hash"`
+	Id         int       `json:"id"`
+	Ip         string    `json:"ip"`
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// PrevHash Hash of the previous event in the chain
+	PrevHash string `json:"prevHash"`
+
+	// Result success | failure | denied
+	Result    string `json:"result"`
+	Target    string `json:"target"`
+	UserAgent string `json:"userAgent"`
+}
+
 // BuildInfo defines model for BuildInfo.
 type BuildInfo struct {
 	// Arch Architecture of the machine used for the build
@@ -27,14 +86,130 @@ type BuildInfo struct {
 	Version string `json:"version"`
 }
 
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+	Username        string `json:"username"`
+}
+
+// CreateOrganizationRequest defines model for CreateOrganizationRequest.
+type CreateOrganizationRequest struct {
+	// Name Display name
+	Name     string                `json:"name"`
+	Settings *OrganizationSettings `json:"settings,omitempty"`
+
+	// Slug Unique short name used in URLs and the tenant claim
+	Slug string `json:"slug"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	// Events Event types, "*" for all of them
+	Events []CreateWebhookRequestEvents `json:"events"`
+
+	// Secret HMAC-SHA256 key, generated if omitted
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Endpoint receiving POST requests
+	Url string `json:"url"`
+}
+
+// CreateWebhookRequestEvents defines model for CreateWebhookRequest.Events.
+type CreateWebhookRequestEvents string
+
+// DeviceAuthorization defines model for DeviceAuthorization.
+type DeviceAuthorization struct {
+	ClientId  string    `json:"clientId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Scope     string    `json:"scope"`
+	UserCode  string    `json:"userCode"`
+}
+
+// DeviceCodeRequest defines model for DeviceCodeRequest.
+type DeviceCodeRequest struct {
+	// ClientId Identifier of the device application, goes to the client_id claim
+	ClientId string `json:"client_id"`
+
+	// Scope Space separated scopes of the token
+	Scope *string `json:"scope,omitempty"`
+}
+
+// DeviceCodeResponse defines model for DeviceCodeResponse.
+type DeviceCodeResponse struct {
+	DeviceCode string `json:"device_code"`
+
+	// ExpiresIn Lifetime of both codes in seconds
+	ExpiresIn int `json:"expires_in"`
+
+	// Interval Minimum seconds between polls of /token
+	Interval int `json:"interval"`
+
+	// UserCode Short code the user enters on the verification page, e.g. BDWP-HQPK
+	UserCode        string `json:"user_code"`
+	VerificationUri string `json:"verification_uri"`
+
+	// VerificationUriComplete Verification page with user_code prefilled, e.g. for a QR code
+	VerificationUriComplete *string `json:"verification_uri_complete,omitempty"`
+}
+
+// DeviceDecisionRequest defines model for DeviceDecisionRequest.
+type DeviceDecisionRequest struct {
+	// Approve false denies the request
+	Approve  bool   `json:"approve"`
+	UserCode string `json:"userCode"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Error Description of the error
 	Error string `json:"error"`
 }
 
+// ImpersonateRequest defines model for ImpersonateRequest.
+type ImpersonateRequest struct {
+	// Reason Why impersonation is needed, e.g. support ticket id
+	Reason string `json:"reason"`
+
+	// Username Username of the user to impersonate
+	Username string `json:"username"`
+}
+
+// ImpersonateResponse defines model for ImpersonateResponse.
+type ImpersonateResponse struct {
+	// AccessToken Short-lived token for the target user carrying the admin as actor
+	AccessToken string `json:"accessToken"`
+
+	// ExpiresIn Token lifetime in seconds
+	ExpiresIn int `json:"expiresIn"`
+}
+
+// JWK defines model for JWK.
+type JWK struct {
+	// Alg EdDSA | RS256 | PS256 | ES256
+	Alg string  `json:"alg"`
+	Crv *string `json:"crv,omitempty"`
+	E   *string `json:"e,omitempty"`
+	Kid string  `json:"kid"`
+
+	// Kty Key type, OKP | RSA | EC
+	Kty string  `json:"kty"`
+	N   *string `json:"n,omitempty"`
+	Use string  `json:"use"`
+	X   *string `json:"x,omitempty"`
+	Y   *string `json:"y,omitempty"`
+}
+
+// JWKS defines model for JWKS.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
 // LoginUserRequest defines model for LoginUserRequest.
 type LoginUserRequest struct {
+	// Organization Slug of the organization to log into, token gets its tenant claim
+	Organization *string `json:"organization,omitempty"`
+
 	// Password Password of the existing user
 	Password string `json:"password"`
 
@@ -48,8 +223,54 @@ type LoginUserResponse struct {
 	AccessToken string `json:"accessToken"`
 }
 
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	// Username Username (email) of the existing user
+	Username string `json:"username"`
+}
+
+// Membership defines model for Membership.
+type Membership struct {
+	// Role Role of the user inside the organization
+	Role     OrgRole `json:"role"`
+	Username string  `json:"username"`
+}
+
+// OAuthError defines model for OAuthError.
+type OAuthError struct {
+	// Error Error code, e.g. invalid_request, invalid_grant, invalid_target
+	Error            string  `json:"error"`
+	ErrorDescription *string `json:"error_description,omitempty"`
+}
+
+// OrgRole Role of the user inside the organization
+type OrgRole string
+
+// Organization defines model for Organization.
+type Organization struct {
+	Id       int                  `json:"id"`
+	Name     string               `json:"name"`
+	Settings OrganizationSettings `json:"settings"`
+	Slug     string               `json:"slug"`
+}
+
+// OrganizationSettings defines model for OrganizationSettings.
+type OrganizationSettings struct {
+	// RegistrationPolicy Whether anyone may self-register into the organization
+	RegistrationPolicy *RegistrationPolicy `json:"registrationPolicy,omitempty"`
+}
+
 // RegisterUserRequest defines model for RegisterUserRequest.
 type RegisterUserRequest struct {
+	// DisplayName Name shown to other users
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Email Contact email of the new user
+	Email *string `json:"email,omitempty"`
+
+	// Organization Slug of the organization to join
+	Organization *string `json:"organization,omitempty"`
+
 	// Password Password of the new user
 	Password string `json:"password"`
 
@@ -60,14 +281,219 @@ type RegisterUserRequest struct {
 // RegisterUserResponse defines model for RegisterUserResponse.
 type RegisterUserResponse struct {
 	// Id Unique identifier for the registered user
-	Id int `json:"id"`
+	Id int64 `json:"id"`
 
 	// Username Username of the newly registered user
 	Username string `json:"username"`
 }
 
+// RegistrationPolicy Whether anyone may self-register into the organization
+type RegistrationPolicy string
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"newPassword"`
+}
+
+// SetMemberRequest defines model for SetMemberRequest.
+type SetMemberRequest struct {
+	// Role Role of the user inside the organization
+	Role OrgRole `json:"role"`
+}
+
+// TokenRequest defines model for TokenRequest.
+type TokenRequest struct {
+	// ActorToken Token of the service acting on behalf of the subject
+	ActorToken *string `json:"actor_token,omitempty"`
+
+	// ActorTokenType Required when actor_token is present
+	ActorTokenType *string `json:"actor_token_type,omitempty"`
+
+	// Audience Space separated audiences of the new token, has to be a subset of the subject token ones
+	Audience *string `json:"audience,omitempty"`
+
+	// ClientId Client the device code was issued to
+	ClientId *string `json:"client_id,omitempty"`
+
+	// DeviceCode Device code from /device/code
+	DeviceCode *string `json:"device_code,omitempty"`
+
+	// GrantType urn:ietf:params:oauth:grant-type:token-exchange or urn:ietf:params:oauth:grant-type:device_code
+	GrantType string `json:"grant_type"`
+
+	// RequestedTokenType Only urn:ietf:params:oauth:token-type:access_token is supported
+	RequestedTokenType *string `json:"requested_token_type,omitempty"`
+
+	// Scope Space separated scopes of the new token, has to be a subset of the subject token ones
+	Scope *string `json:"scope,omitempty"`
+
+	// SubjectToken Token of the user the new token is issued for
+	SubjectToken *string `json:"subject_token,omitempty"`
+
+	// SubjectTokenType urn:ietf:params:oauth:token-type:access_token or urn:ietf:params:oauth:token-type:jwt
+	SubjectTokenType *string `json:"subject_token_type,omitempty"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Token lifetime in seconds
+	ExpiresIn       int     `json:"expires_in"`
+	IssuedTokenType *string `json:"issued_token_type,omitempty"`
+	Scope           *string `json:"scope,omitempty"`
+
+	// TokenType Always Bearer
+	TokenType string `json:"token_type"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts      int        `json:"attempts"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty"`
+	Error         string     `json:"error"`
+	Event         string     `json:"event"`
+	Id            int64      `json:"id"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`
+
+	// ResponseCode HTTP status of the last attempt, 0 if there was no response
+	ResponseCode   int                   `json:"responseCode"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionId int64                 `json:"subscriptionId"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Events    []string  `json:"events"`
+	Id        int64     `json:"id"`
+
+	// Secret Only returned on creation
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// AuditAction defines model for AuditAction.
+type AuditAction = string
+
+// AuditActor defines model for AuditActor.
+type AuditActor = string
+
+// AuditResult defines model for AuditResult.
+type AuditResult = string
+
+// AuditSince defines model for AuditSince.
+type AuditSince = time.Time
+
+// AuditTarget defines model for AuditTarget.
+type AuditTarget = string
+
+// AuditUntil defines model for AuditUntil.
+type AuditUntil = time.Time
+
+// OrgSlug defines model for OrgSlug.
+type OrgSlug = string
+
+// GetAdminAuditParams defines parameters for GetAdminAudit.
+type GetAdminAuditParams struct {
+	// Actor Who performed the action
+	Actor *AuditActor `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Action Action name, e.g. login or register
+	Action *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+
+	// Target Whom or what the action was performed on
+	Target *AuditTarget `form:"target,omitempty" json:"target,omitempty"`
+
+	// Result success | failure | denied
+	Result *AuditResult `form:"result,omitempty" json:"result,omitempty"`
+
+	// Since Only events at or after this time
+	Since *AuditSince `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only events before this time
+	Until *AuditUntil `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit Max number of events to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAdminAuditExportParams defines parameters for GetAdminAuditExport.
+type GetAdminAuditExportParams struct {
+	// Actor Who performed the action
+	Actor *AuditActor `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Action Action name, e.g. login or register
+	Action *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+
+	// Target Whom or what the action was performed on
+	Target *AuditTarget `form:"target,omitempty" json:"target,omitempty"`
+
+	// Result success | failure | denied
+	Result *AuditResult `form:"result,omitempty" json:"result,omitempty"`
+
+	// Since Only events at or after this time
+	Since *AuditSince `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only events before this time
+	Until *AuditUntil `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// GetAdminWebhooksIdDeliveriesParams defines parameters for GetAdminWebhooksIdDeliveries.
+type GetAdminWebhooksIdDeliveriesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetDeviceParams defines parameters for GetDevice.
+type GetDeviceParams struct {
+	UserCode string `form:"user_code" json:"user_code"`
+}
+
+// GetLoginMagicVerifyParams defines parameters for GetLoginMagicVerify.
+type GetLoginMagicVerifyParams struct {
+	// Token Single-use token from the login link
+	Token string `form:"token" json:"token"`
+}
+
+// PostAdminImpersonateJSONRequestBody defines body for PostAdminImpersonate for application/json ContentType.
+type PostAdminImpersonateJSONRequestBody = ImpersonateRequest
+
+// PostAdminUsersUsernamePasswordJSONRequestBody defines body for PostAdminUsersUsernamePassword for application/json ContentType.
+type PostAdminUsersUsernamePasswordJSONRequestBody = ResetPasswordRequest
+
+// PostAdminWebhooksJSONRequestBody defines body for PostAdminWebhooks for application/json ContentType.
+type PostAdminWebhooksJSONRequestBody = CreateWebhookRequest
+
+// PostDeviceJSONRequestBody defines body for PostDevice for application/json ContentType.
+type PostDeviceJSONRequestBody = DeviceDecisionRequest
+
+// PostDeviceCodeFormdataRequestBody defines body for PostDeviceCode for application/x-www-form-urlencoded ContentType.
+type PostDeviceCodeFormdataRequestBody = DeviceCodeRequest
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody = LoginUserRequest
 
+// PostLoginMagicJSONRequestBody defines body for PostLoginMagic for application/json ContentType.
+type PostLoginMagicJSONRequestBody = MagicLinkRequest
+
+// PostOrgsJSONRequestBody defines body for PostOrgs for application/json ContentType.
+type PostOrgsJSONRequestBody = CreateOrganizationRequest
+
+// PutOrgsSlugMembersUsernameJSONRequestBody defines body for PutOrgsSlugMembersUsername for application/json ContentType.
+type PutOrgsSlugMembersUsernameJSONRequestBody = SetMemberRequest
+
+// PatchOrgsSlugSettingsJSONRequestBody defines body for PatchOrgsSlugSettings for application/json ContentType.
+type PatchOrgsSlugSettingsJSONRequestBody = OrganizationSettings
+
+// PostPasswordChangeJSONRequestBody defines body for PostPasswordChange for application/json ContentType.
+type PostPasswordChangeJSONRequestBody = ChangePasswordRequest
+
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody = RegisterUserRequest
+
+// PostTokenFormdataRequestBody defines body for PostToken for application/x-www-form-urlencoded ContentType.
+type PostTokenFormdataRequestBody = TokenRequest
@@ -1,6 +1,6 @@
 // Package gen provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package gen
 
 import (
@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -17,44 +18,252 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Public keys to verify issued tokens
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request)
+	// Query security audit events, newest first
+	// (GET /admin/audit)
+	GetAdminAudit(w http.ResponseWriter, r *http.Request, params GetAdminAuditParams)
+	// Export matching audit events as JSON lines, oldest first
+	// (GET /admin/audit/export)
+	GetAdminAuditExport(w http.ResponseWriter, r *http.Request, params GetAdminAuditExportParams)
+	// Issue a short-lived token to act as another user
+	// (POST /admin/impersonate)
+	PostAdminImpersonate(w http.ResponseWriter, r *http.Request)
+	// Delete user account
+	// (DELETE /admin/users/{username})
+	DeleteAdminUsersUsername(w http.ResponseWriter, r *http.Request, username string)
+	// Reset user password
+	// (POST /admin/users/{username}/password)
+	PostAdminUsersUsernamePassword(w http.ResponseWriter, r *http.Request, username string)
+	// List webhook subscriptions
+	// (GET /admin/webhooks)
+	GetAdminWebhooks(w http.ResponseWriter, r *http.Request)
+	// Subscribe an endpoint to user lifecycle events
+	// (POST /admin/webhooks)
+	PostAdminWebhooks(w http.ResponseWriter, r *http.Request)
+	// Queue the same payload once more
+	// (POST /admin/webhooks/deliveries/{id}/redeliver)
+	PostAdminWebhooksDeliveriesIdRedeliver(w http.ResponseWriter, r *http.Request, id int64)
+	// Delete webhook subscription with its delivery log
+	// (DELETE /admin/webhooks/{id})
+	DeleteAdminWebhooksId(w http.ResponseWriter, r *http.Request, id int64)
+	// Delivery log of the subscription, newest first
+	// (GET /admin/webhooks/{id}/deliveries)
+	GetAdminWebhooksIdDeliveries(w http.ResponseWriter, r *http.Request, id int64, params GetAdminWebhooksIdDeliveriesParams)
 	// Get build information
 	// (GET /buildinfo)
 	GetBuildinfo(w http.ResponseWriter, r *http.Request)
+	// Pending device authorization to show on the verification page
+	// (GET /device)
+	GetDevice(w http.ResponseWriter, r *http.Request, params GetDeviceParams)
+	// Approve or deny device authorization
+	// (POST /device)
+	PostDevice(w http.ResponseWriter, r *http.Request)
+	// Start device authorization (RFC 8628)
+	// (POST /device/code)
+	PostDeviceCode(w http.ResponseWriter, r *http.Request)
 	// Login a user
 	// (POST /login)
 	PostLogin(w http.ResponseWriter, r *http.Request)
+	// Request a passwordless login link
+	// (POST /login/magic)
+	PostLoginMagic(w http.ResponseWriter, r *http.Request)
+	// Exchange a login link token for an access token
+	// (GET /login/magic/verify)
+	GetLoginMagicVerify(w http.ResponseWriter, r *http.Request, params GetLoginMagicVerifyParams)
+	// Create an organization
+	// (POST /orgs)
+	PostOrgs(w http.ResponseWriter, r *http.Request)
+	// Get organization with its settings
+	// (GET /orgs/{slug})
+	GetOrgsSlug(w http.ResponseWriter, r *http.Request, slug OrgSlug)
+	// List organization members
+	// (GET /orgs/{slug}/members)
+	GetOrgsSlugMembers(w http.ResponseWriter, r *http.Request, slug OrgSlug)
+	// Add a user to the organization or change their role
+	// (PUT /orgs/{slug}/members/{username})
+	PutOrgsSlugMembersUsername(w http.ResponseWriter, r *http.Request, slug OrgSlug, username string)
+	// Update organization settings
+	// (PATCH /orgs/{slug}/settings)
+	PatchOrgsSlugSettings(w http.ResponseWriter, r *http.Request, slug OrgSlug)
+	// Change own password, works for expired passwords as well
+	// (POST /password/change)
+	PostPasswordChange(w http.ResponseWriter, r *http.Request)
 	// Register a new user
 	// (POST /register)
 	PostRegister(w http.ResponseWriter, r *http.Request)
+	// OAuth 2.0 token endpoint
+	// (POST /token)
+	PostToken(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Public keys to verify issued tokens
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Query security audit events, newest first
+// (GET /admin/audit)
+func (_ Unimplemented) GetAdminAudit(w http.ResponseWriter, r *http.Request, params GetAdminAuditParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export matching audit events as JSON lines, oldest first
+// (GET /admin/audit/export)
+func (_ Unimplemented) GetAdminAuditExport(w http.ResponseWriter, r *http.Request, params GetAdminAuditExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue a short-lived token to act as another user
+// (POST /admin/impersonate)
+func (_ Unimplemented) PostAdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete user account
+// (DELETE /admin/users/{username})
+func (_ Unimplemented) DeleteAdminUsersUsername(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset user password
+// (POST /admin/users/{username}/password)
+func (_ Unimplemented) PostAdminUsersUsernamePassword(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook subscriptions
+// (GET /admin/webhooks)
+func (_ Unimplemented) GetAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Subscribe an endpoint to user lifecycle events
+// (POST /admin/webhooks)
+func (_ Unimplemented) PostAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Queue the same payload once more
+// (POST /admin/webhooks/deliveries/{id}/redeliver)
+func (_ Unimplemented) PostAdminWebhooksDeliveriesIdRedeliver(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete webhook subscription with its delivery log
+// (DELETE /admin/webhooks/{id})
+func (_ Unimplemented) DeleteAdminWebhooksId(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delivery log of the subscription, newest first
+// (GET /admin/webhooks/{id}/deliveries)
+func (_ Unimplemented) GetAdminWebhooksIdDeliveries(w http.ResponseWriter, r *http.Request, id int64, params GetAdminWebhooksIdDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get build information
 // (GET /buildinfo)
 func (_ Unimplemented) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Pending device authorization to show on the verification page
+// (GET /device)
+func (_ Unimplemented) GetDevice(w http.ResponseWriter, r *http.Request, params GetDeviceParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve or deny device authorization
+// (POST /device)
+func (_ Unimplemented) PostDevice(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start device authorization (RFC 8628)
+// (POST /device/code)
+func (_ Unimplemented) PostDeviceCode(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Login a user
 // (POST /login)
 func (_ Unimplemented) PostLogin(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Request a passwordless login link
+// (POST /login/magic)
+func (_ Unimplemented) PostLoginMagic(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchange a login link token for an access token
+// (GET /login/magic/verify)
+func (_ Unimplemented) GetLoginMagicVerify(w http.ResponseWriter, r *http.Request, params GetLoginMagicVerifyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an organization
+// (POST /orgs)
+func (_ Unimplemented) PostOrgs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get organization with its settings
+// (GET /orgs/{slug})
+func (_ Unimplemented) GetOrgsSlug(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List organization members
+// (GET /orgs/{slug}/members)
+func (_ Unimplemented) GetOrgsSlugMembers(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a user to the organization or change their role
+// (PUT /orgs/{slug}/members/{username})
+func (_ Unimplemented) PutOrgsSlugMembersUsername(w http.ResponseWriter, r *http.Request, slug OrgSlug, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update organization settings
+// (PATCH /orgs/{slug}/settings)
+func (_ Unimplemented) PatchOrgsSlugSettings(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change own password, works for expired passwords as well
+// (POST /password/change)
+func (_ Unimplemented) PostPasswordChange(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Register a new user
 // (POST /register)
 func (_ Unimplemented) PostRegister(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// OAuth 2.0 token endpoint
+// (POST /token)
+func (_ Unimplemented) PostToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -64,340 +273,3019 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetBuildinfo operation middleware
-func (siw *ServerInterfaceWrapper) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// GetWellKnownJwksJson operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBuildinfo(w, r)
+		siw.Handler.GetWellKnownJwksJson(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	handler.ServeHTTP(w, r)
 }
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(w http.ResponseWriter, r *http.Request) {
+// GetAdminAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAudit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAuditParams
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "result" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "result", r.URL.Query(), &params.Result)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "result", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostLogin(w, r)
+		siw.Handler.GetAdminAudit(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	handler.ServeHTTP(w, r)
 }
 
-// PostRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostRegister(w http.ResponseWriter, r *http.Request) {
+// GetAdminAuditExport operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAuditExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAuditExportParams
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "result" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "result", r.URL.Query(), &params.Result)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "result", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostRegister(w, r)
+		siw.Handler.GetAdminAuditExport(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
-
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+	handler.ServeHTTP(w, r)
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// PostAdminImpersonate operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminImpersonate(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	ctx := r.Context()
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	r = r.WithContext(ctx)
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminImpersonate(w, r)
+	}))
 
-type RequiredParamError struct {
-	ParamName string
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+// DeleteAdminUsersUsername operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminUsersUsername(w http.ResponseWriter, r *http.Request) {
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	var err error
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "username" -------------
+	var username string
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	r = r.WithContext(ctx)
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminUsersUsername(w, r, username)
+	}))
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+// PostAdminUsersUsernamePassword operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersUsernamePassword(w http.ResponseWriter, r *http.Request) {
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+	var err error
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	// ------------- Path parameter "username" -------------
+	var username string
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
 	}
-	wrapper := ServerInterfaceWrapper{
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersUsernamePassword(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminWebhooksDeliveriesIdRedeliver operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminWebhooksDeliveriesIdRedeliver(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminWebhooksDeliveriesIdRedeliver(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAdminWebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminWebhooksId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminWebhooksId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminWebhooksIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminWebhooksIdDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminWebhooksIdDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminWebhooksIdDeliveries(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBuildinfo operation middleware
+func (siw *ServerInterfaceWrapper) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBuildinfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDevice operation middleware
+func (siw *ServerInterfaceWrapper) GetDevice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDeviceParams
+
+	// ------------- Required query parameter "user_code" -------------
+
+	if paramValue := r.URL.Query().Get("user_code"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_code"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_code", r.URL.Query(), &params.UserCode)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_code", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDevice(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDevice operation middleware
+func (siw *ServerInterfaceWrapper) PostDevice(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDevice(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDeviceCode operation middleware
+func (siw *ServerInterfaceWrapper) PostDeviceCode(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDeviceCode(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostLoginMagic operation middleware
+func (siw *ServerInterfaceWrapper) PostLoginMagic(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLoginMagic(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLoginMagicVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetLoginMagicVerify(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLoginMagicVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLoginMagicVerify(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostOrgs operation middleware
+func (siw *ServerInterfaceWrapper) PostOrgs(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostOrgs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrgsSlug operation middleware
+func (siw *ServerInterfaceWrapper) GetOrgsSlug(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug OrgSlug
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrgsSlug(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrgsSlugMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetOrgsSlugMembers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug OrgSlug
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrgsSlugMembers(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutOrgsSlugMembersUsername operation middleware
+func (siw *ServerInterfaceWrapper) PutOrgsSlugMembersUsername(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug OrgSlug
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutOrgsSlugMembersUsername(w, r, slug, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchOrgsSlugSettings operation middleware
+func (siw *ServerInterfaceWrapper) PatchOrgsSlugSettings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug OrgSlug
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchOrgsSlugSettings(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPasswordChange operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordChange(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPasswordChange(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostToken operation middleware
+func (siw *ServerInterfaceWrapper) PostToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
 		Handler:            si,
 		HandlerMiddlewares: options.Middlewares,
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/buildinfo", wrapper.GetBuildinfo)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/login", wrapper.PostLogin)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/register", wrapper.PostRegister)
-	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetWellKnownJwksJson)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit", wrapper.GetAdminAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit/export", wrapper.GetAdminAuditExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/impersonate", wrapper.PostAdminImpersonate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{username}", wrapper.DeleteAdminUsersUsername)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{username}/password", wrapper.PostAdminUsersUsernamePassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/webhooks", wrapper.GetAdminWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/webhooks", wrapper.PostAdminWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/webhooks/deliveries/{id}/redeliver", wrapper.PostAdminWebhooksDeliveriesIdRedeliver)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/webhooks/{id}", wrapper.DeleteAdminWebhooksId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/webhooks/{id}/deliveries", wrapper.GetAdminWebhooksIdDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/buildinfo", wrapper.GetBuildinfo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/device", wrapper.GetDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/device", wrapper.PostDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/device/code", wrapper.PostDeviceCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/login", wrapper.PostLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/login/magic", wrapper.PostLoginMagic)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/login/magic/verify", wrapper.GetLoginMagicVerify)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/orgs", wrapper.PostOrgs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orgs/{slug}", wrapper.GetOrgsSlug)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/orgs/{slug}/members", wrapper.GetOrgsSlugMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/orgs/{slug}/members/{username}", wrapper.PutOrgsSlugMembersUsername)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/orgs/{slug}/settings", wrapper.PatchOrgsSlugSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/password/change", wrapper.PostPasswordChange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/register", wrapper.PostRegister)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/token", wrapper.PostToken)
+	})
+
+	return r
+}
+
+type GetWellKnownJwksJsonRequestObject struct {
+}
+
+type GetWellKnownJwksJsonResponseObject interface {
+	VisitGetWellKnownJwksJsonResponse(w http.ResponseWriter) error
+}
+
+type GetWellKnownJwksJson200JSONResponse JWKS
+
+func (response GetWellKnownJwksJson200JSONResponse) VisitGetWellKnownJwksJsonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAuditRequestObject struct {
+	Params GetAdminAuditParams
+}
+
+type GetAdminAuditResponseObject interface {
+	VisitGetAdminAuditResponse(w http.ResponseWriter) error
+}
+
+type GetAdminAudit200JSONResponse []AuditEvent
+
+func (response GetAdminAudit200JSONResponse) VisitGetAdminAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAudit400JSONResponse ErrorResponse
+
+func (response GetAdminAudit400JSONResponse) VisitGetAdminAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAudit401JSONResponse ErrorResponse
+
+func (response GetAdminAudit401JSONResponse) VisitGetAdminAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAudit403JSONResponse ErrorResponse
+
+func (response GetAdminAudit403JSONResponse) VisitGetAdminAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAudit500JSONResponse ErrorResponse
+
+func (response GetAdminAudit500JSONResponse) VisitGetAdminAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAuditExportRequestObject struct {
+	Params GetAdminAuditExportParams
+}
+
+type GetAdminAuditExportResponseObject interface {
+	VisitGetAdminAuditExportResponse(w http.ResponseWriter) error
+}
+
+type GetAdminAuditExport200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetAdminAuditExport200ApplicationxNdjsonResponse) VisitGetAdminAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetAdminAuditExport400JSONResponse ErrorResponse
+
+func (response GetAdminAuditExport400JSONResponse) VisitGetAdminAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAuditExport401JSONResponse ErrorResponse
+
+func (response GetAdminAuditExport401JSONResponse) VisitGetAdminAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAuditExport403JSONResponse ErrorResponse
+
+func (response GetAdminAuditExport403JSONResponse) VisitGetAdminAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminAuditExport500JSONResponse ErrorResponse
+
+func (response GetAdminAuditExport500JSONResponse) VisitGetAdminAuditExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonateRequestObject struct {
+	Body *PostAdminImpersonateJSONRequestBody
+}
+
+type PostAdminImpersonateResponseObject interface {
+	VisitPostAdminImpersonateResponse(w http.ResponseWriter) error
+}
+
+type PostAdminImpersonate200JSONResponse ImpersonateResponse
+
+func (response PostAdminImpersonate200JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonate400JSONResponse ErrorResponse
+
+func (response PostAdminImpersonate400JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonate401JSONResponse ErrorResponse
+
+func (response PostAdminImpersonate401JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonate403JSONResponse ErrorResponse
+
+func (response PostAdminImpersonate403JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonate404JSONResponse ErrorResponse
+
+func (response PostAdminImpersonate404JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminImpersonate500JSONResponse ErrorResponse
+
+func (response PostAdminImpersonate500JSONResponse) VisitPostAdminImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminUsersUsernameRequestObject struct {
+	Username string `json:"username"`
+}
+
+type DeleteAdminUsersUsernameResponseObject interface {
+	VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error
+}
+
+type DeleteAdminUsersUsername204Response struct {
+}
+
+func (response DeleteAdminUsersUsername204Response) VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAdminUsersUsername401JSONResponse ErrorResponse
+
+func (response DeleteAdminUsersUsername401JSONResponse) VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminUsersUsername403JSONResponse ErrorResponse
+
+func (response DeleteAdminUsersUsername403JSONResponse) VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminUsersUsername404JSONResponse ErrorResponse
+
+func (response DeleteAdminUsersUsername404JSONResponse) VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminUsersUsername500JSONResponse ErrorResponse
+
+func (response DeleteAdminUsersUsername500JSONResponse) VisitDeleteAdminUsersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUsernamePasswordRequestObject struct {
+	Username string `json:"username"`
+	Body     *PostAdminUsersUsernamePasswordJSONRequestBody
+}
+
+type PostAdminUsersUsernamePasswordResponseObject interface {
+	VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersUsernamePassword204Response struct {
+}
+
+func (response PostAdminUsersUsernamePassword204Response) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostAdminUsersUsernamePassword400JSONResponse ErrorResponse
+
+func (response PostAdminUsersUsernamePassword400JSONResponse) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUsernamePassword401JSONResponse ErrorResponse
+
+func (response PostAdminUsersUsernamePassword401JSONResponse) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUsernamePassword403JSONResponse ErrorResponse
+
+func (response PostAdminUsersUsernamePassword403JSONResponse) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUsernamePassword404JSONResponse ErrorResponse
+
+func (response PostAdminUsersUsernamePassword404JSONResponse) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersUsernamePassword500JSONResponse ErrorResponse
+
+func (response PostAdminUsersUsernamePassword500JSONResponse) VisitPostAdminUsersUsernamePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksRequestObject struct {
+}
+
+type GetAdminWebhooksResponseObject interface {
+	VisitGetAdminWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetAdminWebhooks200JSONResponse []WebhookSubscription
+
+func (response GetAdminWebhooks200JSONResponse) VisitGetAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooks401JSONResponse ErrorResponse
+
+func (response GetAdminWebhooks401JSONResponse) VisitGetAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooks403JSONResponse ErrorResponse
+
+func (response GetAdminWebhooks403JSONResponse) VisitGetAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooks500JSONResponse ErrorResponse
+
+func (response GetAdminWebhooks500JSONResponse) VisitGetAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksRequestObject struct {
+	Body *PostAdminWebhooksJSONRequestBody
+}
+
+type PostAdminWebhooksResponseObject interface {
+	VisitPostAdminWebhooksResponse(w http.ResponseWriter) error
+}
+
+type PostAdminWebhooks201JSONResponse WebhookSubscription
+
+func (response PostAdminWebhooks201JSONResponse) VisitPostAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooks400JSONResponse ErrorResponse
+
+func (response PostAdminWebhooks400JSONResponse) VisitPostAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooks401JSONResponse ErrorResponse
+
+func (response PostAdminWebhooks401JSONResponse) VisitPostAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooks403JSONResponse ErrorResponse
+
+func (response PostAdminWebhooks403JSONResponse) VisitPostAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooks500JSONResponse ErrorResponse
+
+func (response PostAdminWebhooks500JSONResponse) VisitPostAdminWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliverRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliverResponseObject interface {
+	VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliver202JSONResponse WebhookDelivery
+
+func (response PostAdminWebhooksDeliveriesIdRedeliver202JSONResponse) VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliver401JSONResponse ErrorResponse
+
+func (response PostAdminWebhooksDeliveriesIdRedeliver401JSONResponse) VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliver403JSONResponse ErrorResponse
+
+func (response PostAdminWebhooksDeliveriesIdRedeliver403JSONResponse) VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliver404JSONResponse ErrorResponse
+
+func (response PostAdminWebhooksDeliveriesIdRedeliver404JSONResponse) VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminWebhooksDeliveriesIdRedeliver500JSONResponse ErrorResponse
+
+func (response PostAdminWebhooksDeliveriesIdRedeliver500JSONResponse) VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminWebhooksIdRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteAdminWebhooksIdResponseObject interface {
+	VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteAdminWebhooksId204Response struct {
+}
+
+func (response DeleteAdminWebhooksId204Response) VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAdminWebhooksId401JSONResponse ErrorResponse
+
+func (response DeleteAdminWebhooksId401JSONResponse) VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminWebhooksId403JSONResponse ErrorResponse
+
+func (response DeleteAdminWebhooksId403JSONResponse) VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminWebhooksId404JSONResponse ErrorResponse
+
+func (response DeleteAdminWebhooksId404JSONResponse) VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminWebhooksId500JSONResponse ErrorResponse
+
+func (response DeleteAdminWebhooksId500JSONResponse) VisitDeleteAdminWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveriesRequestObject struct {
+	Id     int64 `json:"id"`
+	Params GetAdminWebhooksIdDeliveriesParams
+}
+
+type GetAdminWebhooksIdDeliveriesResponseObject interface {
+	VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type GetAdminWebhooksIdDeliveries200JSONResponse []WebhookDelivery
+
+func (response GetAdminWebhooksIdDeliveries200JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveries400JSONResponse ErrorResponse
+
+func (response GetAdminWebhooksIdDeliveries400JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveries401JSONResponse ErrorResponse
+
+func (response GetAdminWebhooksIdDeliveries401JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveries403JSONResponse ErrorResponse
+
+func (response GetAdminWebhooksIdDeliveries403JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveries404JSONResponse ErrorResponse
+
+func (response GetAdminWebhooksIdDeliveries404JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminWebhooksIdDeliveries500JSONResponse ErrorResponse
+
+func (response GetAdminWebhooksIdDeliveries500JSONResponse) VisitGetAdminWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBuildinfoRequestObject struct {
+}
+
+type GetBuildinfoResponseObject interface {
+	VisitGetBuildinfoResponse(w http.ResponseWriter) error
+}
+
+type GetBuildinfo200JSONResponse BuildInfo
+
+func (response GetBuildinfo200JSONResponse) VisitGetBuildinfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBuildinfo500JSONResponse ErrorResponse
+
+func (response GetBuildinfo500JSONResponse) VisitGetBuildinfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceRequestObject struct {
+	Params GetDeviceParams
+}
+
+type GetDeviceResponseObject interface {
+	VisitGetDeviceResponse(w http.ResponseWriter) error
+}
+
+type GetDevice200JSONResponse DeviceAuthorization
+
+func (response GetDevice200JSONResponse) VisitGetDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevice401JSONResponse ErrorResponse
+
+func (response GetDevice401JSONResponse) VisitGetDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevice404JSONResponse ErrorResponse
+
+func (response GetDevice404JSONResponse) VisitGetDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevice500JSONResponse ErrorResponse
+
+func (response GetDevice500JSONResponse) VisitGetDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDeviceRequestObject struct {
+	Body *PostDeviceJSONRequestBody
+}
+
+type PostDeviceResponseObject interface {
+	VisitPostDeviceResponse(w http.ResponseWriter) error
+}
+
+type PostDevice204Response struct {
+}
+
+func (response PostDevice204Response) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostDevice400JSONResponse ErrorResponse
+
+func (response PostDevice400JSONResponse) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDevice401JSONResponse ErrorResponse
+
+func (response PostDevice401JSONResponse) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDevice403JSONResponse ErrorResponse
+
+func (response PostDevice403JSONResponse) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDevice404JSONResponse ErrorResponse
+
+func (response PostDevice404JSONResponse) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDevice500JSONResponse ErrorResponse
+
+func (response PostDevice500JSONResponse) VisitPostDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDeviceCodeRequestObject struct {
+	Body *PostDeviceCodeFormdataRequestBody
+}
+
+type PostDeviceCodeResponseObject interface {
+	VisitPostDeviceCodeResponse(w http.ResponseWriter) error
+}
+
+type PostDeviceCode200JSONResponse DeviceCodeResponse
+
+func (response PostDeviceCode200JSONResponse) VisitPostDeviceCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDeviceCode400JSONResponse OAuthError
+
+func (response PostDeviceCode400JSONResponse) VisitPostDeviceCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDeviceCode500JSONResponse OAuthError
+
+func (response PostDeviceCode500JSONResponse) VisitPostDeviceCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginRequestObject struct {
+	Body *PostLoginJSONRequestBody
+}
+
+type PostLoginResponseObject interface {
+	VisitPostLoginResponse(w http.ResponseWriter) error
+}
+
+type PostLogin200JSONResponse LoginUserResponse
+
+func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin400JSONResponse ErrorResponse
+
+func (response PostLogin400JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin401JSONResponse ErrorResponse
+
+func (response PostLogin401JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin403JSONResponse ErrorResponse
+
+func (response PostLogin403JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin500JSONResponse ErrorResponse
+
+func (response PostLogin500JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMagicRequestObject struct {
+	Body *PostLoginMagicJSONRequestBody
+}
+
+type PostLoginMagicResponseObject interface {
+	VisitPostLoginMagicResponse(w http.ResponseWriter) error
+}
+
+type PostLoginMagic202Response struct {
+}
+
+func (response PostLoginMagic202Response) VisitPostLoginMagicResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type PostLoginMagic400JSONResponse ErrorResponse
+
+func (response PostLoginMagic400JSONResponse) VisitPostLoginMagicResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMagic404JSONResponse ErrorResponse
+
+func (response PostLoginMagic404JSONResponse) VisitPostLoginMagicResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMagic500JSONResponse ErrorResponse
+
+func (response PostLoginMagic500JSONResponse) VisitPostLoginMagicResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoginMagicVerifyRequestObject struct {
+	Params GetLoginMagicVerifyParams
+}
+
+type GetLoginMagicVerifyResponseObject interface {
+	VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error
+}
+
+type GetLoginMagicVerify200JSONResponse LoginUserResponse
+
+func (response GetLoginMagicVerify200JSONResponse) VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoginMagicVerify401JSONResponse ErrorResponse
+
+func (response GetLoginMagicVerify401JSONResponse) VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoginMagicVerify403JSONResponse ErrorResponse
+
+func (response GetLoginMagicVerify403JSONResponse) VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoginMagicVerify404JSONResponse ErrorResponse
+
+func (response GetLoginMagicVerify404JSONResponse) VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLoginMagicVerify500JSONResponse ErrorResponse
+
+func (response GetLoginMagicVerify500JSONResponse) VisitGetLoginMagicVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgsRequestObject struct {
+	Body *PostOrgsJSONRequestBody
+}
+
+type PostOrgsResponseObject interface {
+	VisitPostOrgsResponse(w http.ResponseWriter) error
+}
+
+type PostOrgs201JSONResponse Organization
+
+func (response PostOrgs201JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgs400JSONResponse ErrorResponse
+
+func (response PostOrgs400JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgs401JSONResponse ErrorResponse
+
+func (response PostOrgs401JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgs403JSONResponse ErrorResponse
+
+func (response PostOrgs403JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgs409JSONResponse ErrorResponse
+
+func (response PostOrgs409JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrgs500JSONResponse ErrorResponse
+
+func (response PostOrgs500JSONResponse) VisitPostOrgsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugRequestObject struct {
+	Slug OrgSlug `json:"slug"`
+}
+
+type GetOrgsSlugResponseObject interface {
+	VisitGetOrgsSlugResponse(w http.ResponseWriter) error
+}
+
+type GetOrgsSlug200JSONResponse Organization
+
+func (response GetOrgsSlug200JSONResponse) VisitGetOrgsSlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlug401JSONResponse ErrorResponse
+
+func (response GetOrgsSlug401JSONResponse) VisitGetOrgsSlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlug403JSONResponse ErrorResponse
+
+func (response GetOrgsSlug403JSONResponse) VisitGetOrgsSlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlug404JSONResponse ErrorResponse
+
+func (response GetOrgsSlug404JSONResponse) VisitGetOrgsSlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlug500JSONResponse ErrorResponse
+
+func (response GetOrgsSlug500JSONResponse) VisitGetOrgsSlugResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugMembersRequestObject struct {
+	Slug OrgSlug `json:"slug"`
+}
+
+type GetOrgsSlugMembersResponseObject interface {
+	VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error
+}
+
+type GetOrgsSlugMembers200JSONResponse []Membership
+
+func (response GetOrgsSlugMembers200JSONResponse) VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugMembers401JSONResponse ErrorResponse
+
+func (response GetOrgsSlugMembers401JSONResponse) VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugMembers403JSONResponse ErrorResponse
+
+func (response GetOrgsSlugMembers403JSONResponse) VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugMembers404JSONResponse ErrorResponse
+
+func (response GetOrgsSlugMembers404JSONResponse) VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrgsSlugMembers500JSONResponse ErrorResponse
+
+func (response GetOrgsSlugMembers500JSONResponse) VisitGetOrgsSlugMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsernameRequestObject struct {
+	Slug     OrgSlug `json:"slug"`
+	Username string  `json:"username"`
+	Body     *PutOrgsSlugMembersUsernameJSONRequestBody
+}
+
+type PutOrgsSlugMembersUsernameResponseObject interface {
+	VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error
+}
+
+type PutOrgsSlugMembersUsername200JSONResponse Membership
+
+func (response PutOrgsSlugMembersUsername200JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsername400JSONResponse ErrorResponse
+
+func (response PutOrgsSlugMembersUsername400JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsername401JSONResponse ErrorResponse
+
+func (response PutOrgsSlugMembersUsername401JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsername403JSONResponse ErrorResponse
+
+func (response PutOrgsSlugMembersUsername403JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsername404JSONResponse ErrorResponse
+
+func (response PutOrgsSlugMembersUsername404JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrgsSlugMembersUsername500JSONResponse ErrorResponse
+
+func (response PutOrgsSlugMembersUsername500JSONResponse) VisitPutOrgsSlugMembersUsernameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettingsRequestObject struct {
+	Slug OrgSlug `json:"slug"`
+	Body *PatchOrgsSlugSettingsJSONRequestBody
+}
+
+type PatchOrgsSlugSettingsResponseObject interface {
+	VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error
+}
+
+type PatchOrgsSlugSettings200JSONResponse Organization
+
+func (response PatchOrgsSlugSettings200JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettings400JSONResponse ErrorResponse
+
+func (response PatchOrgsSlugSettings400JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettings401JSONResponse ErrorResponse
+
+func (response PatchOrgsSlugSettings401JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettings403JSONResponse ErrorResponse
+
+func (response PatchOrgsSlugSettings403JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettings404JSONResponse ErrorResponse
+
+func (response PatchOrgsSlugSettings404JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchOrgsSlugSettings500JSONResponse ErrorResponse
+
+func (response PatchOrgsSlugSettings500JSONResponse) VisitPatchOrgsSlugSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChangeRequestObject struct {
+	Body *PostPasswordChangeJSONRequestBody
+}
+
+type PostPasswordChangeResponseObject interface {
+	VisitPostPasswordChangeResponse(w http.ResponseWriter) error
+}
+
+type PostPasswordChange204Response struct {
+}
+
+func (response PostPasswordChange204Response) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostPasswordChange400JSONResponse ErrorResponse
+
+func (response PostPasswordChange400JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange401JSONResponse ErrorResponse
+
+func (response PostPasswordChange401JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange403JSONResponse ErrorResponse
+
+func (response PostPasswordChange403JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange500JSONResponse ErrorResponse
+
+func (response PostPasswordChange500JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegisterRequestObject struct {
+	Body *PostRegisterJSONRequestBody
+}
+
+type PostRegisterResponseObject interface {
+	VisitPostRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostRegister201JSONResponse RegisterUserResponse
+
+func (response PostRegister201JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister400JSONResponse ErrorResponse
+
+func (response PostRegister400JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister403JSONResponse ErrorResponse
+
+func (response PostRegister403JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister409JSONResponse ErrorResponse
+
+func (response PostRegister409JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister500JSONResponse ErrorResponse
+
+func (response PostRegister500JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTokenRequestObject struct {
+	Body *PostTokenFormdataRequestBody
+}
+
+type PostTokenResponseObject interface {
+	VisitPostTokenResponse(w http.ResponseWriter) error
+}
+
+type PostToken200JSONResponse TokenResponse
+
+func (response PostToken200JSONResponse) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostToken400JSONResponse OAuthError
+
+func (response PostToken400JSONResponse) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostToken500JSONResponse OAuthError
+
+func (response PostToken500JSONResponse) VisitPostTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Public keys to verify issued tokens
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(ctx context.Context, request GetWellKnownJwksJsonRequestObject) (GetWellKnownJwksJsonResponseObject, error)
+	// Query security audit events, newest first
+	// (GET /admin/audit)
+	GetAdminAudit(ctx context.Context, request GetAdminAuditRequestObject) (GetAdminAuditResponseObject, error)
+	// Export matching audit events as JSON lines, oldest first
+	// (GET /admin/audit/export)
+	GetAdminAuditExport(ctx context.Context, request GetAdminAuditExportRequestObject) (GetAdminAuditExportResponseObject, error)
+	// Issue a short-lived token to act as another user
+	// (POST /admin/impersonate)
+	PostAdminImpersonate(ctx context.Context, request PostAdminImpersonateRequestObject) (PostAdminImpersonateResponseObject, error)
+	// Delete user account
+	// (DELETE /admin/users/{username})
+	DeleteAdminUsersUsername(ctx context.Context, request DeleteAdminUsersUsernameRequestObject) (DeleteAdminUsersUsernameResponseObject, error)
+	// Reset user password
+	// (POST /admin/users/{username}/password)
+	PostAdminUsersUsernamePassword(ctx context.Context, request PostAdminUsersUsernamePasswordRequestObject) (PostAdminUsersUsernamePasswordResponseObject, error)
+	// List webhook subscriptions
+	// (GET /admin/webhooks)
+	GetAdminWebhooks(ctx context.Context, request GetAdminWebhooksRequestObject) (GetAdminWebhooksResponseObject, error)
+	// Subscribe an endpoint to user lifecycle events
+	// (POST /admin/webhooks)
+	PostAdminWebhooks(ctx context.Context, request PostAdminWebhooksRequestObject) (PostAdminWebhooksResponseObject, error)
+	// Queue the same payload once more
+	// (POST /admin/webhooks/deliveries/{id}/redeliver)
+	PostAdminWebhooksDeliveriesIdRedeliver(ctx context.Context, request PostAdminWebhooksDeliveriesIdRedeliverRequestObject) (PostAdminWebhooksDeliveriesIdRedeliverResponseObject, error)
+	// Delete webhook subscription with its delivery log
+	// (DELETE /admin/webhooks/{id})
+	DeleteAdminWebhooksId(ctx context.Context, request DeleteAdminWebhooksIdRequestObject) (DeleteAdminWebhooksIdResponseObject, error)
+	// Delivery log of the subscription, newest first
+	// (GET /admin/webhooks/{id}/deliveries)
+	GetAdminWebhooksIdDeliveries(ctx context.Context, request GetAdminWebhooksIdDeliveriesRequestObject) (GetAdminWebhooksIdDeliveriesResponseObject, error)
+	// Get build information
+	// (GET /buildinfo)
+	GetBuildinfo(ctx context.Context, request GetBuildinfoRequestObject) (GetBuildinfoResponseObject, error)
+	// Pending device authorization to show on the verification page
+	// (GET /device)
+	GetDevice(ctx context.Context, request GetDeviceRequestObject) (GetDeviceResponseObject, error)
+	// Approve or deny device authorization
+	// (POST /device)
+	PostDevice(ctx context.Context, request PostDeviceRequestObject) (PostDeviceResponseObject, error)
+	// Start device authorization (RFC 8628)
+	// (POST /device/code)
+	PostDeviceCode(ctx context.Context, request PostDeviceCodeRequestObject) (PostDeviceCodeResponseObject, error)
+	// Login a user
+	// (POST /login)
+	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
+	// Request a passwordless login link
+	// (POST /login/magic)
+	PostLoginMagic(ctx context.Context, request PostLoginMagicRequestObject) (PostLoginMagicResponseObject, error)
+	// Exchange a login link token for an access token
+	// (GET /login/magic/verify)
+	GetLoginMagicVerify(ctx context.Context, request GetLoginMagicVerifyRequestObject) (GetLoginMagicVerifyResponseObject, error)
+	// Create an organization
+	// (POST /orgs)
+	PostOrgs(ctx context.Context, request PostOrgsRequestObject) (PostOrgsResponseObject, error)
+	// Get organization with its settings
+	// (GET /orgs/{slug})
+	GetOrgsSlug(ctx context.Context, request GetOrgsSlugRequestObject) (GetOrgsSlugResponseObject, error)
+	// List organization members
+	// (GET /orgs/{slug}/members)
+	GetOrgsSlugMembers(ctx context.Context, request GetOrgsSlugMembersRequestObject) (GetOrgsSlugMembersResponseObject, error)
+	// Add a user to the organization or change their role
+	// (PUT /orgs/{slug}/members/{username})
+	PutOrgsSlugMembersUsername(ctx context.Context, request PutOrgsSlugMembersUsernameRequestObject) (PutOrgsSlugMembersUsernameResponseObject, error)
+	// Update organization settings
+	// (PATCH /orgs/{slug}/settings)
+	PatchOrgsSlugSettings(ctx context.Context, request PatchOrgsSlugSettingsRequestObject) (PatchOrgsSlugSettingsResponseObject, error)
+	// Change own password, works for expired passwords as well
+	// (POST /password/change)
+	PostPasswordChange(ctx context.Context, request PostPasswordChangeRequestObject) (PostPasswordChangeResponseObject, error)
+	// Register a new user
+	// (POST /register)
+	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+	// OAuth 2.0 token endpoint
+	// (POST /token)
+	PostToken(ctx context.Context, request PostTokenRequestObject) (PostTokenResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetWellKnownJwksJson operation middleware
+func (sh *strictHandler) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	var request GetWellKnownJwksJsonRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWellKnownJwksJson(ctx, request.(GetWellKnownJwksJsonRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWellKnownJwksJson")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWellKnownJwksJsonResponseObject); ok {
+		if err := validResponse.VisitGetWellKnownJwksJsonResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminAudit operation middleware
+func (sh *strictHandler) GetAdminAudit(w http.ResponseWriter, r *http.Request, params GetAdminAuditParams) {
+	var request GetAdminAuditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminAudit(ctx, request.(GetAdminAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminAuditResponseObject); ok {
+		if err := validResponse.VisitGetAdminAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminAuditExport operation middleware
+func (sh *strictHandler) GetAdminAuditExport(w http.ResponseWriter, r *http.Request, params GetAdminAuditExportParams) {
+	var request GetAdminAuditExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminAuditExport(ctx, request.(GetAdminAuditExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminAuditExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminAuditExportResponseObject); ok {
+		if err := validResponse.VisitGetAdminAuditExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminImpersonate operation middleware
+func (sh *strictHandler) PostAdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	var request PostAdminImpersonateRequestObject
+
+	var body PostAdminImpersonateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminImpersonate(ctx, request.(PostAdminImpersonateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminImpersonate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminImpersonateResponseObject); ok {
+		if err := validResponse.VisitPostAdminImpersonateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAdminUsersUsername operation middleware
+func (sh *strictHandler) DeleteAdminUsersUsername(w http.ResponseWriter, r *http.Request, username string) {
+	var request DeleteAdminUsersUsernameRequestObject
+
+	request.Username = username
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAdminUsersUsername(ctx, request.(DeleteAdminUsersUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAdminUsersUsername")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAdminUsersUsernameResponseObject); ok {
+		if err := validResponse.VisitDeleteAdminUsersUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersUsernamePassword operation middleware
+func (sh *strictHandler) PostAdminUsersUsernamePassword(w http.ResponseWriter, r *http.Request, username string) {
+	var request PostAdminUsersUsernamePasswordRequestObject
+
+	request.Username = username
+
+	var body PostAdminUsersUsernamePasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersUsernamePassword(ctx, request.(PostAdminUsersUsernamePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersUsernamePassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminUsersUsernamePasswordResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersUsernamePasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminWebhooks operation middleware
+func (sh *strictHandler) GetAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request GetAdminWebhooksRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminWebhooks(ctx, request.(GetAdminWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetAdminWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminWebhooks operation middleware
+func (sh *strictHandler) PostAdminWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request PostAdminWebhooksRequestObject
+
+	var body PostAdminWebhooksJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminWebhooks(ctx, request.(PostAdminWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminWebhooksResponseObject); ok {
+		if err := validResponse.VisitPostAdminWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminWebhooksDeliveriesIdRedeliver operation middleware
+func (sh *strictHandler) PostAdminWebhooksDeliveriesIdRedeliver(w http.ResponseWriter, r *http.Request, id int64) {
+	var request PostAdminWebhooksDeliveriesIdRedeliverRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminWebhooksDeliveriesIdRedeliver(ctx, request.(PostAdminWebhooksDeliveriesIdRedeliverRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminWebhooksDeliveriesIdRedeliver")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminWebhooksDeliveriesIdRedeliverResponseObject); ok {
+		if err := validResponse.VisitPostAdminWebhooksDeliveriesIdRedeliverResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAdminWebhooksId operation middleware
+func (sh *strictHandler) DeleteAdminWebhooksId(w http.ResponseWriter, r *http.Request, id int64) {
+	var request DeleteAdminWebhooksIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAdminWebhooksId(ctx, request.(DeleteAdminWebhooksIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAdminWebhooksId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAdminWebhooksIdResponseObject); ok {
+		if err := validResponse.VisitDeleteAdminWebhooksIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminWebhooksIdDeliveries operation middleware
+func (sh *strictHandler) GetAdminWebhooksIdDeliveries(w http.ResponseWriter, r *http.Request, id int64, params GetAdminWebhooksIdDeliveriesParams) {
+	var request GetAdminWebhooksIdDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminWebhooksIdDeliveries(ctx, request.(GetAdminWebhooksIdDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminWebhooksIdDeliveries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminWebhooksIdDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetAdminWebhooksIdDeliveriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBuildinfo operation middleware
+func (sh *strictHandler) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
+	var request GetBuildinfoRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBuildinfo(ctx, request.(GetBuildinfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBuildinfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBuildinfoResponseObject); ok {
+		if err := validResponse.VisitGetBuildinfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDevice operation middleware
+func (sh *strictHandler) GetDevice(w http.ResponseWriter, r *http.Request, params GetDeviceParams) {
+	var request GetDeviceRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDevice(ctx, request.(GetDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDevice")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDeviceResponseObject); ok {
+		if err := validResponse.VisitGetDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostDevice operation middleware
+func (sh *strictHandler) PostDevice(w http.ResponseWriter, r *http.Request) {
+	var request PostDeviceRequestObject
+
+	var body PostDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDevice(ctx, request.(PostDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDevice")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostDeviceResponseObject); ok {
+		if err := validResponse.VisitPostDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostDeviceCode operation middleware
+func (sh *strictHandler) PostDeviceCode(w http.ResponseWriter, r *http.Request) {
+	var request PostDeviceCodeRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostDeviceCodeFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDeviceCode(ctx, request.(PostDeviceCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDeviceCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostDeviceCodeResponseObject); ok {
+		if err := validResponse.VisitPostDeviceCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogin operation middleware
+func (sh *strictHandler) PostLogin(w http.ResponseWriter, r *http.Request) {
+	var request PostLoginRequestObject
+
+	var body PostLoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return r
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogin")
+	}
 
-type GetBuildinfoRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type GetBuildinfoResponseObject interface {
-	VisitGetBuildinfoResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
+		if err := validResponse.VisitPostLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetBuildinfo200JSONResponse BuildInfo
-
-func (response GetBuildinfo200JSONResponse) VisitGetBuildinfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PostLoginMagic operation middleware
+func (sh *strictHandler) PostLoginMagic(w http.ResponseWriter, r *http.Request) {
+	var request PostLoginMagicRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PostLoginMagicJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetBuildinfo500JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLoginMagic(ctx, request.(PostLoginMagicRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLoginMagic")
+	}
 
-func (response GetBuildinfo500JSONResponse) VisitGetBuildinfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostLoginMagicResponseObject); ok {
+		if err := validResponse.VisitPostLoginMagicResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostLoginRequestObject struct {
-	Body *PostLoginJSONRequestBody
-}
+// GetLoginMagicVerify operation middleware
+func (sh *strictHandler) GetLoginMagicVerify(w http.ResponseWriter, r *http.Request, params GetLoginMagicVerifyParams) {
+	var request GetLoginMagicVerifyRequestObject
 
-type PostLoginResponseObject interface {
-	VisitPostLoginResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type PostLogin200JSONResponse LoginUserResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLoginMagicVerify(ctx, request.(GetLoginMagicVerifyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLoginMagicVerify")
+	}
 
-func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLoginMagicVerifyResponseObject); ok {
+		if err := validResponse.VisitGetLoginMagicVerifyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostLogin400JSONResponse ErrorResponse
-
-func (response PostLogin400JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// PostOrgs operation middleware
+func (sh *strictHandler) PostOrgs(w http.ResponseWriter, r *http.Request) {
+	var request PostOrgsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PostOrgsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type PostLogin401JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOrgs(ctx, request.(PostOrgsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOrgs")
+	}
 
-func (response PostLogin401JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostOrgsResponseObject); ok {
+		if err := validResponse.VisitPostOrgsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostLogin500JSONResponse ErrorResponse
+// GetOrgsSlug operation middleware
+func (sh *strictHandler) GetOrgsSlug(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	var request GetOrgsSlugRequestObject
 
-func (response PostLogin500JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Slug = slug
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrgsSlug(ctx, request.(GetOrgsSlugRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrgsSlug")
+	}
 
-type PostRegisterRequestObject struct {
-	Body *PostRegisterJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PostRegisterResponseObject interface {
-	VisitPostRegisterResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOrgsSlugResponseObject); ok {
+		if err := validResponse.VisitGetOrgsSlugResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRegister201JSONResponse RegisterUserResponse
-
-func (response PostRegister201JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+// GetOrgsSlugMembers operation middleware
+func (sh *strictHandler) GetOrgsSlugMembers(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	var request GetOrgsSlugMembersRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Slug = slug
 
-type PostRegister400JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrgsSlugMembers(ctx, request.(GetOrgsSlugMembersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrgsSlugMembers")
+	}
 
-func (response PostRegister400JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOrgsSlugMembersResponseObject); ok {
+		if err := validResponse.VisitGetOrgsSlugMembersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRegister500JSONResponse ErrorResponse
-
-func (response PostRegister500JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// PutOrgsSlugMembersUsername operation middleware
+func (sh *strictHandler) PutOrgsSlugMembersUsername(w http.ResponseWriter, r *http.Request, slug OrgSlug, username string) {
+	var request PutOrgsSlugMembersUsernameRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Slug = slug
+	request.Username = username
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get build information
-	// (GET /buildinfo)
-	GetBuildinfo(ctx context.Context, request GetBuildinfoRequestObject) (GetBuildinfoResponseObject, error)
-	// Login a user
-	// (POST /login)
-	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
-	// Register a new user
-	// (POST /register)
-	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
-}
+	var body PutOrgsSlugMembersUsernameJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutOrgsSlugMembersUsername(ctx, request.(PutOrgsSlugMembersUsernameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutOrgsSlugMembersUsername")
+	}
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutOrgsSlugMembersUsernameResponseObject); ok {
+		if err := validResponse.VisitPutOrgsSlugMembersUsernameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+// PatchOrgsSlugSettings operation middleware
+func (sh *strictHandler) PatchOrgsSlugSettings(w http.ResponseWriter, r *http.Request, slug OrgSlug) {
+	var request PatchOrgsSlugSettingsRequestObject
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+	request.Slug = slug
 
-// GetBuildinfo operation middleware
-func (sh *strictHandler) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
-	var request GetBuildinfoRequestObject
+	var body PatchOrgsSlugSettingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetBuildinfo(ctx, request.(GetBuildinfoRequestObject))
+		return sh.ssi.PatchOrgsSlugSettings(ctx, request.(PatchOrgsSlugSettingsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetBuildinfo")
+		handler = middleware(handler, "PatchOrgsSlugSettings")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetBuildinfoResponseObject); ok {
-		if err := validResponse.VisitGetBuildinfoResponse(w); err != nil {
+	} else if validResponse, ok := response.(PatchOrgsSlugSettingsResponseObject); ok {
+		if err := validResponse.VisitPatchOrgsSlugSettingsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -405,11 +3293,11 @@ func (sh *strictHandler) GetBuildinfo(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// PostLogin operation middleware
-func (sh *strictHandler) PostLogin(w http.ResponseWriter, r *http.Request) {
-	var request PostLoginRequestObject
+// PostPasswordChange operation middleware
+func (sh *strictHandler) PostPasswordChange(w http.ResponseWriter, r *http.Request) {
+	var request PostPasswordChangeRequestObject
 
-	var body PostLoginJSONRequestBody
+	var body PostPasswordChangeJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -417,18 +3305,18 @@ func (sh *strictHandler) PostLogin(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+		return sh.ssi.PostPasswordChange(ctx, request.(PostPasswordChangeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostLogin")
+		handler = middleware(handler, "PostPasswordChange")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
-		if err := validResponse.VisitPostLoginResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostPasswordChangeResponseObject); ok {
+		if err := validResponse.VisitPostPasswordChangeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -467,23 +3355,127 @@ func (sh *strictHandler) PostRegister(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PostToken operation middleware
+func (sh *strictHandler) PostToken(w http.ResponseWriter, r *http.Request) {
+	var request PostTokenRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostTokenFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostToken(ctx, request.(PostTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostTokenResponseObject); ok {
+		if err := validResponse.VisitPostTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xWTW/bOBD9KwR3j4Y/9uPiW7y7WLgIUCON20NRBIw4lphKpDIcJnUD/feCpCx/SEqc",
-	"wklzsq0hZ96b9zTjB56YojQaNFk+feA2yaAQ4evMqVzO9cr4HyWaEpAUhJDAJPOfEmyCqiRlNJ/yM0wy",
-	"RZCQQ2BmxSgDVogkUxqYsyDZymB4eO0z8wGndQl8yi2h0imvBjwErqQgaGf/V1CTtTdBYopC0VUmbAe+",
-	"f0KQ+eAmkTUOE2CJkdCTrlQ5YGeuEDmSWGqu7gBtuHuY6n/DSjQpiqJQOmW50KkTKbD6wpEVjG1nfl8C",
-	"CvJJ7doSFEem6kX6sUb0uArVgCPcOoUg+fRzk21fnD2t9/oTqAyixXYU+NLUMdc3kJAH+h+iwQuwpdEW",
-	"2i4FH+4w0vbXhkk8+RSTeKoLyLlJlV5awAu4dWCpjaUU1t4blG04izrSYPmmbNDMWcAuefxzLYqOV2RZ",
-	"R45MdUCvyTvYon2CbF/nRZKAtZfmK3S46N2nS0Y+FJwYj3qQJRo/PEAyNI7APgl4t0oX0AtIlSXAEwmj",
-	"4f4EmvRn+Uk59ln2KaI6CC61unXAlARNaqUAm9GAdU6QB1iVJkgBn005X/fn7OGv/GhpirSZ+/Oq3k4H",
-	"e2gxD0z85bqsH4NGM6Ely719fXVFOdRo2ZmjzHchiefOFnO+Mwf5ZDgejsOULUGLUvEp/3M4Hk6CNpSF",
-	"Do/CQNsgSiF4zcQJbPRc+kkPNGsOebZRrXD9j/HYfyRGE+hwV5RlXgMa3dg4j+N69t9+R1jxKf9ttN3f",
-	"o3p5j7abO3RpvzshyDwELCJbBEIFdyCZdeGVWrk8X3u6f58Q1P6w7gA21+TFztkHwDtAFi4EW1hXFALX",
-	"sYNx7ewSCGdGUVdvfGM7er8wls5r6TEOg5mR65PRa62Aat/QhA6qF9S8PZU7Why8viuyfxtSkEp7tf96",
-	"TbVnQrKmVb725PVqL7VwlBlU30G+SZsHMZmIgzK4ezM9Hzf4Zhe8kMe7FupRNp+8EITnOH27f36119+c",
-	"3TZNZWL796SqqupHAAAA//9VcSloHQ4AAA==",
+	"H4sIAAAAAAAC/+x9XXPbttLwX8HwfWfO6RnactK0z6nvHCdtnKSNazvHF03GA5ErCTEFsABoWU/q//7M",
+	"4oMCRVCiHVtxenSlEQkCi8V+YXex+JxkYloKDlyrZP9zUlJJp6BBmn8HVc70QaaZ4Pg3B5VJVtq/iX1O",
+	"OJ1CSmB3vEsKMWacCEkkjJnSIJM0Ydj0zwrkPEkTbJvsJ9T2mCYqm8CUYtd6XuIbpSXj4+TmJq3HFrI9",
+	"9PlEkBLkSMgp5ERPgNRddownZJ/hTkBVhW6Pp6osA6XIX2REWVFJIH+RHDiDvGNEaTvqMeQp4xm0R3zH",
+	"izmBK1wWQjXilI40SKInTBHNptAxsDLdheMikqhO9pOcathxn3YAc0blGHQU31OEYTahOkA3mVEVrEMn",
+	"/rXttgc23nPNitXYGMJISFiLiMr0dAdEvJPj06IaR4CQY8rZ/1Izc4VN3Mgl1ZNgBewbCX9WTEKe7GtZ",
+	"waqp3/iXC5Z7iVPFf6UUJUjNwLyjNScu9ZE6Eu/NKCmBaannZISExQWfT0WlCMIMSqs2XtIkB01ZoaKD",
+	"T6iatMc+fXWw8/SHH4m4AklKCVevqJoQyi0cZjXJiEGRR8djeTAU4xrGIM3zMgqCyLJKSsgPdN+lThMP",
+	"Uxt0A6kYGUCxFUPsWIgZN0+zCWU81qm8iwhp9aJrRmy9qhTIgzHw2NubkO7+QBw2MJPWkrAWlzVrsjIJ",
+	"+04XIsyvfIAwt+Qfa8jF8BNkGsF7XrEiP+IjESFfmUVwfSCzCdOQacSJw/mUZhPGgVQKckOj+HCIPceQ",
+	"ZV5c4FK3e39Bdd1rZweZmE6ZvoiT8aF5SSYBSShRyQxIJnLo6K5kBchoX+ZNz4mNxcUVSBXVvr8IUkox",
+	"lnQ6ZXxMCsrHFR0DcR/0HEGoiJwrQVKNnaq50jDt2VUnpP9xEK1ehSXS9b01F6ex1g38mKmklsSCFYhR",
+	"6OGE8jEcU6VmQuYnVua1qdVwDde+XZQXOcxWvkd+smphHavWLdPWwM1hojOSQDWE+qlzVh6aJS5hqizo",
+	"nDgIWvNQoJEgTA//X8Io2U/+32BhOA6c/hqEIJz6b25SqxNbo77n7M8KiJoIqc3QltIYJ+9P3qpaU2jg",
+	"lGuSFZRN19KN075mIt2oOofhRIjLTixZU6MNsdHLBDtVKfmQ/OtDYjVoUTjqRgCZhqnthVdTv7a73iQ2",
+	"8t48uQLJRmzxv3RLfJEZAq2f51CANn//lXxszb9+QKWkc7tYmYwZca9+PTjcOX11gDr5EuYpGQNHTkeU",
+	"j4iYMq3jyqiSEZPsJc9LwbgmEjJgVygujt+dnq2wIZYpXqJ55jAdW6oXcMUyOKj0REhHUxEuLRhwfRRn",
+	"P7gumQR1G5tAZaKETl4+FHnsZYSXD61uqMHzPYdAdU8av+4WTKbPC5a3F+UoB66RqKSXtrnpj9CyLFhG",
+	"rek3FqCIFuZ93VkXfwUoWTLvSpoBUVBSS0OmmfLjanEJfC0NLKayDheqFFxBGxl2fhdZfGFqbF+wiGJ6",
+	"y0aAdIAwD4WeGIWuUP4oyAQP7dLQAOUa5BWNsMSvjLNpNfVfkyHoGQAnpSgKg5jBElaCXpFm6lksIdrI",
+	"R3xnMIstCSAQighrjFpJYpeXlHTs9+PPX5wf77z6/fhNh76uP7qoJIuib7nRBQp9FEdRNd8EgsyYnpB6",
+	"YmhIj1hRQO6gM5KT/H7SYUctkUq40CG6IhNprHqwYN009gIyplapTVqWUlxFZj2ihQJryCuzFk7+LeYz",
+	"FKIAyu8sQvzQMehfSilkN3MAvo5o+8U/z6625bo1sK1igBxNS5BKcKq75ZYEqmL24flkTlj9PQLFFOEA",
+	"eU0pqipL5AHNskvQhMW1VGBrLdkZ7o2frOEgLYJB15NfYJ+5eaxFQ9eqULMRPDOiIM7sOwW7wu06NqkN",
+	"b7tTs8BnVMo5qlx8QfMp44Qq4jd3XULwKDKeAYMUXhKulH1LKAnnEY4RQ8zr8zcRRBQRo/Bl/uL0gPxF",
+	"Tk7RTvmLHLvfl/gb3W3Jq7jkjz69ZHFT4VLP27C8gbkx9lLy7s2xgQkhe3kYA4N3GQ3R59fRp/P1ggHh",
+	"tLNIDf7sEB0oP23j/BLm5rc2UleZ87hqLQNzGSDsMDb+W3QGI+t1CgQR7BYinFBUY8+xYUvk3EKMCeNa",
+	"pI5HxqAVYVqt2SukSRns1prD+Q2WHxKumTKbYGS4L5M3a7rqljTlqk1fgN87SZrX52eBhLFNEchSCg0Z",
+	"WnRSVBrWG/LhKDFAf6Vjlr1lvHu71QOV/4QpZcV3X4jSKHgwHYJUE1a2AZOigB573hNsdtftvhkjBtg7",
+	"3Pq89Cq8l2Y3rY095TQn41e0YPmFs0rS+sFYUh78rd2AbTGKXV40hvl8ZzPB46oFOD5tqGfGFcuhxfxJ",
+	"Wu+qp2bdkjQx+i+6M363JGCaOOzyMHes4P15QXo4bUM3RjBwB07bo0WML3Q/SNPqWBQsm6+bxUn7i5ub",
+	"yPgnzrGxUtTn1sH0W5TJ8Sl6gWZGtgs9sQ5SGY0LGDkQ86xyTTNNzGtPSRxmneL77srnk4h7/vvrllVw",
+	"9Vcr3b3cUaM0V7JLqbC805PHFu4Hb7Uu3F4e1toPw7j+8VnnZrg3Dop5ZJAeLLZSKZxE2WV53wKGVCmf",
+	"C44xizlRUIx2PDTGQlklwURpLOesEAryqAA7AQV6rad6tRd6ae7rfMmnoK1G7BzuVkpxafROZWfMh84h",
+	"zc7mQscNGfNpHZoBaR1emTEPBCdDmNBiVL+v7JBpRwjVDnJhX7a0lJsJmU2Ak6A97lhLCcpGzto9VzkD",
+	"nvVwovmWKmRyM0SKESgUP0MgFKehQC9NyjYkgkNUcq7wGx6aV6HD0HhsMLzPlKrMXjQeFG5435bdDIue",
+	"RlJMycA2H3TFzYxN0oH6SvJ9Bnq0bxJU1L6glZ7smy928It9M/cduLbOcyIkWftJ06UUieQaaoR8JU2Y",
+	"1IT4UBYkM5S1kRfE4jwacX/7nRyu90gorlUvfrO+lHB8wmqiGQm5tv9brXcXRjtXO/jg00yvVQwBBa4Q",
+	"Uqu3Wwu03coZfRtHTJpYBC9h8BbBjFWoPyhmdK7Ic6Cyhy5tTLrRcWO+MXS6KNwLQHeXnEcQqjVMS63i",
+	"dnpmQnm3yvjI7VC3+6jea7XfXMXTMHz2Sg9Th8O1PrDTvA1M0hHhYVTyvjo7OyZKU13V4qGgShOHz5Ts",
+	"YcQPjRcr47kgvsMokLarMKRZAs8RlAClaNpRVnRYMiiHPIRH/ZAT3Rc1u/FLUIOYLmhmCUl+HZdRHtLR",
+	"CiI9DQaOhORuT4qLEHPtilsb2e1NVV0hYKOsJOhKcpOvRwzgLhOoI+zbx5QOQ7mrEWqBqyTT81O0FS3+",
+	"hkbWoL9j8e9nP83X52c+j8/EU5bk0kTr0mbRMZd1tCTOjo/MbsRoqnAbbBIMTMoqdsZ0AW6DQRAQ4NoH",
+	"sw6Oj5IgvyV5sru3u2f2kCVwWrJkP/l+d2/3idlf6YmZ0WB3BkWxc8nFjA8+zS7V7icX/IgmWGIu7RUQ",
+	"xcYczdZLmBvo0LlKlGZF4fLOmDTpdAUtyYzxXMxSUgql2LCYI6fnbDQCCVwTWoyFZHoyxQURNqPHcl7y",
+	"C+hzKIo3CNrr2aV6rQQPuMWA/3RvD38ywbUTcUEEeeCnsshpXONFPrULtOSAPH33GzmHIUE3+yk44qim",
+	"U4q6IDmuhgXLLAq0sDHO+cIcvQSuzBcD4/8ZoOWsA/y25nyAzUx2ZZI20pz/iIO/aDIIUpFv0t6tcZZ9",
+	"m7vE277NXZ5y3+Y2x7hva5uDi62Xwtv0mvAKt4ZIai4bVwsnTzrScAs2Zc3M3xxGFIHff7K3lyZTeo0x",
+	"c/MP/9oQerL/JKIPPn4hkfaKeQT5t+3QR4uGf6U6myDHGvJzSMEPn90jAzWjvBEontOc+L2zGfvJ5sZ+",
+	"z6nL0IHcDv795gb/Wcghy3NARkt+2CTKj7gGyWlBTkFifrP5oKHejGAJFdsfH28+hgLud+QU4ps3KCjF",
+	"7RQozI+WSrfE3ACuSyF7SruXtu1W5vWQebeTMNc7PG+TVW2eDRmnRhRGEv6XzTIgC7lDjGK0BhMpQZKC",
+	"cdjKlK1M6SFTLLOTaUwtEaosaSE9qZSIIo+KmDAtBnc6QkVEzLFQVsYEKS9J7TN7LvL5veEtklt0c3Oz",
+	"fMLm5gEt2FhaT2z9GklM3hmG9uqWe78K9z7be7a5kc+C/CwuNBmJiuffogw5QpJF13ErFU0LDHqYVDO+",
+	"CNOGsgP/q8FnH1u7sQa/TxhtipAX5rkRIrjrVu/DMGXDVImcsmvk4/U9addW78/iIUbic+63vLOZaX/z",
+	"TGOJ2XI/zTJRcb2KLwZhssAaDdtgjuBU0EMyyf1r8Wgou5cef7YioUJirxvXrr/BjPgFJEwRCZ9sxtxw",
+	"bo+MmowBc2CaKnuoSUIGXBfzrUTZSpR+EsUwjBUotbAIJMrMxiXUWifAuW+4Ce9ZLFrSw40WtlcpseEL",
+	"RagEs4aMZ0WV/1er42+Net8ypYmjURJG7Yx3dI3Ga5Ds/aui6BnQXqro/kgvyiirGYO4uFrqEp2QR0wu",
+	"icmcFBjaE9bntd1rbhl0HYM6ysIkIU7AH+bVwmoczEPJ5lkBdUijrXkGLvTPQA0+s/xmIME96WHUehZ/",
+	"UfdxlJ/U37eM2+XMMtNsbk+CRQxf87zb5F2fetDeKT69b9b3k+gyMHM/yT8rqLZb0U2NXNPWN208/o40",
+	"Y/UEZkuXdF4Imhv9QKZCQpSfkYl7Omw8+x7l63i1ocE2yK+RXWMDlK2HZ6Ns1cD938HTE7Nt7Yl3ptVC",
+	"eBdi3MlrgQLtvYs7yhca83GwXvr575H40VLL67etwVJsje6tCPuWRFgtnYKjAfUosfQTU3jLZ1h2iarn",
+	"daMHjMYuKszF+AJfEsat3MKVkqAlAwxiuRJ8o6oo5o9p2ep1+QU0GS5PwKLfnlVZhXt73KYjLrBcHDMo",
+	"n/Il4bP7w1+s2FNU4trTXWE7MqPMHPbyZw4rG8GzJV0egXTcpNubm4xjIiSxhy/siUhbYucblFTH9pxB",
+	"Xcaqse5aGN9XZxWk1Z7Gml8ewsUYLyt013CX74coeuX9fw4jrvKFxwGebTD1prYGycaovJ31o0hG+T80",
+	"caWb3FqprTT4Qmlw4PApULzzeVQqhOpy4A+meikQVSa4aROVJpQMpZghfgxTBadDUdIgU7kCbuYLcz5j",
+	"UdxsqTLbB94llOxRWJ8/YwfZ/cCTtFNEHYq8v5i63pnNZjtoPOxUsgCOwOW3lVth+cENp9pFav5FaKlh",
+	"J/iSaz4+ct+iL6jDEgHFvCAU6WXEuC1fevLzIfnxf579RBTYOuk/7D69b35bDVUfa/NUU6njmvWfOIN/",
+	"//j0399ZdrKnpFZ69U05ogfSpq1SUhsmynappa6Mg3CLgburMeSMb3XxRrM+mDK7c0pshSC/wa2P8zfK",
+	"cWAZX5evdZEzRYcF5B+SFFXMh7payoXTnh8SsmMDrXX+1sDVG2AKqwCLGeSPcmdpKJjQIF/TsPRgirW5",
+	"ejC2qeH1QNzdqg/Wi7ufRuq5mkkWjF8SZUr1ByUKTMGwR+Cm26D1Z/C6Y9BhFhtp1JP4oyRShyhC64Sn",
+	"ApRywOM8WpQ7sKdCV7lFFuT7H9t2ndOc8XEBO5UCXx0P7TVzhH4BR8c1J64GwuPwqNyL0tqg4jiri4fY",
+	"mnRpvWcxFdYl0Hxucik3rlUOrH5Y5p8tM69i5pe+Eg8NGCcsOMldzUn7zLK2kGO1Whu9wxYPmRYWu0Vh",
+	"w7lhIQjR44LB+4fa9GxtzJ4BqJ82N/Kh4KOCZfox8Xtf141lLuR60aRux/WDz1jy8maVJkfWP7V1MW93",
+	"lNnf6PWg6vY2TLuN2G5m5Iag/KYjthgZDBlnkWlS14ZdZqWB3f6qPizlaiB/Pc7qlaERVGruU5rDto5V",
+	"cd3y35b/7nCcocGAnru62G7pEGxZxQzaapkDu8/A9uXE9NGfBGxVmN2wNzcUI51iY8JKG23dGtb/ZXLK",
+	"l4r7puXVQZ47by+J1KDGObrdua0pJ32t5oYkC+vdl1Rnk4gEw8dehtXV57/MjLh/iRMvyL9ZqbNuf/C+",
+	"zOlycGIre7Y20rcjcywFNwVNc3eyFDRb7enzZ/ztda0P5fOL3gX7xWUJ/A2e28IE3bCeS+Hu77EXOkji",
+	"Lr4Njrt/PUe/iSDboqw70RDxo3S6W3ImmPPlkZiSmZCXyvjcfUDFvzMlwbBOrWVPf3HFar70t4QkD1Un",
+	"pH2dzIb979F7UHoFz4JLfr+66v7+K+mwXIAyyY+YDtEouLxxh3l9WwxTRNNL4I+SaT25Ebq40sfwY32R",
+	"QDx98dTeIeECaKS++sIlb/30/Xf7hNYXomhJc1CLTUEdh1vU9srwHmufmei+qxMZ8bN/KHebyi45cBeV",
+	"mDRIdxNFRl0ZgiEQTqUUM5+sjD3bzBwJmZC5TZPDNzRzt/XtfuA290/ZVEumrY8zTMNcpKWRimtWNLP9",
+	"c1D7zTy2C1emPv3AVSFmFzkKRnN3Cw5hjgxQg3k9oRYcf1ds6oKTF+Zm1zzIoLU3HXRlbJ4FKQAPnqzZ",
+	"uDNnw9uZ5lUYK8L5D1H+8O+akWl6IE939zxLu7IM2N/N/w0A9akEvKqJAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
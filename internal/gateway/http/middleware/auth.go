@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	jwtmanager "github.com/bogatyr285/auth-go/internal/pkg/jwt"
+)
+
+type ctxKey int
+
+const (
+	identityKey ctxKey = iota
+	clientKey
+)
+
+type TokenVerifier interface {
+	VerifyToken(tokenString string) (jwtmanager.Claims, error)
+}
+
+// Identity - who is calling. For impersonation tokens Subject is the impersonated user
+// and Actor is the admin who really makes the request. Tenant is the organization the token is scoped to.
+type Identity struct {
+	Subject string
+	Actor   string
+	Tenant  string
+}
+
+func (i Identity) Impersonated() bool {
+	return i.Actor != ""
+}
+
+// Authenticate verifies Bearer token if the request has one and puts Identity into the context.
+// Requests without a valid token pass through unauthenticated: public endpoints such as /login must
+// keep working with a stale header, and handlers that need identity answer 401 themselves.
+func Authenticate(v TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := v.VerifyToken(tokenString)
+			if err != nil || claims.Subject == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity := Identity{
+				Subject: claims.Subject,
+				Actor:   claims.ActorSubject(),
+				Tenant:  claims.Tenant,
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+func WithIdentity(ctx context.Context, i Identity) context.Context {
+	return context.WithValue(ctx, identityKey, i)
+}
+
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	i, ok := ctx.Value(identityKey).(Identity)
+	return i, ok
+}
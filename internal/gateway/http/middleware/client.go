@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Client - request origin, used for audit trail
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// RealIP replaces RemoteAddr with the client address from X-Forwarded-For or X-Real-IP, but only
+// when the request comes from one of the trusted proxies - anyone else could forge these headers.
+// X-Forwarded-For is read from the right skipping trusted hops, the leftmost part is up to the client.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	isTrusted := func(addr string) bool {
+		ip, err := netip.ParseAddr(strings.TrimSpace(addr))
+		if err != nil {
+			return false
+		}
+		for _, p := range trusted {
+			if p.Contains(ip.Unmap()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil || !isTrusted(host) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := strings.TrimSpace(r.Header.Get("X-Real-IP"))
+			if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+				hops := strings.Split(strings.Join(forwarded, ","), ",")
+				for i := len(hops) - 1; i >= 0; i-- {
+					ip = strings.TrimSpace(hops[i])
+					if !isTrusted(ip) {
+						break
+					}
+				}
+			}
+			if _, err := netip.ParseAddr(ip); err == nil {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientInfo puts caller IP and user agent into the context.
+// Put it after RealIP to respect X-Forwarded-For of trusted proxies.
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		c := Client{IP: ip, UserAgent: r.UserAgent()}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientKey, c)))
+	})
+}
+
+func ClientFromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(clientKey).(Client)
+	return c
+}
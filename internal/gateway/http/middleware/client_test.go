@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// X-Forwarded-For учитывается только от доверенных прокси
+func TestRealIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{"Untrusted client forges header", "203.0.113.7:5000", map[string]string{"X-Forwarded-For": "1.1.1.1"}, "203.0.113.7"},
+		{"Trusted proxy", "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "198.51.100.2"}, "198.51.100.2"},
+		{"Client prepends fake hop", "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.2, 10.0.0.2"}, "198.51.100.2"},
+		{"X-Real-IP", "10.0.0.1:5000", map[string]string{"X-Real-IP": "198.51.100.3"}, "198.51.100.3"},
+		{"Garbage", "10.0.0.1:5000", map[string]string{"X-Forwarded-For": "not-an-ip"}, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			var client Client
+			RealIP(trusted)(ClientInfo(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				client = ClientFromContext(r.Context())
+			}))).ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.expected, client.IP)
+		})
+	}
+}
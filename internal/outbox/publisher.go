@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+// StdoutPrefix starts every line of the stdout publisher, the same stream carries JSON logs.
+const StdoutPrefix = "outbox: "
+
+// WriterPublisher writes events as JSON lines. Stand-in for a real message bus.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	// set for files: event must be durable before the relay marks it as published
+	file *os.File
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewStdoutPublisher prefixes events with StdoutPrefix so they can be told apart from log records.
+func NewStdoutPublisher() *WriterPublisher {
+	return &WriterPublisher{w: os.Stdout, prefix: StdoutPrefix}
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, e entity.OutboxEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line := append(append([]byte(p.prefix), data...), '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.w.Write(line); err != nil {
+		return err
+	}
+	if p.file != nil {
+		return p.file.Sync()
+	}
+	return nil
+}
+
+// NewFilePublisher appends events to the file at path.
+func NewFilePublisher(path string) (*WriterPublisher, io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &WriterPublisher{w: f, file: f}, f, nil
+}
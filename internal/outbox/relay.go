@@ -0,0 +1,82 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+const batchSize = 100
+
+type Store interface {
+	PendingOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64, at time.Time) error
+}
+
+// Publisher sends event to the message bus. Events may be published more than once
+// (e.g. crash between Publish and marking), consumers should deduplicate by ID.
+type Publisher interface {
+	Publish(ctx context.Context, e entity.OutboxEvent) error
+}
+
+// Relay moves events from the outbox table to the Publisher, giving at-least-once delivery.
+type Relay struct {
+	store    Store
+	pub      Publisher
+	interval time.Duration
+	log      *slog.Logger
+}
+
+func NewRelay(store Store, pub Publisher, interval time.Duration, log *slog.Logger) *Relay {
+	return &Relay{
+		store:    store,
+		pub:      pub,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run publishes pending events every interval until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.PublishPending(ctx); err != nil {
+				r.log.Error("outbox relay", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// PublishPending publishes events in order and marks them as sent. It stops on the first
+// failure so that events are not reordered; the rest is retried on the next call.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		events, err := r.store.PendingOutboxEvents(ctx, batchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, e := range events {
+			if err := r.pub.Publish(ctx, e); err != nil {
+				return published, err
+			}
+			if err := r.store.MarkOutboxEventPublished(ctx, e.ID, time.Now()); err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(events) < batchSize {
+			return published, nil
+		}
+	}
+}
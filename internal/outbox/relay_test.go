@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Проверяем, что события публикуются по порядку, а после сбоя публикация продолжается с неотправленного
+func TestRelayPublishPending(t *testing.T) {
+	store := &memStore{}
+	for i := 1; i <= 3; i++ {
+		store.events = append(store.events, entity.OutboxEvent{
+			ID:      int64(i),
+			Type:    entity.EventUserRegistered,
+			Payload: json.RawMessage(fmt.Sprintf(`{"username":"user%d"}`, i)),
+		})
+	}
+	pub := &flakyPublisher{failOn: 2}
+	relay := NewRelay(store, pub, time.Second, nil)
+
+	published, err := relay.PublishPending(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []int64{1}, pub.ids)
+
+	pub.failOn = 0
+	published, err = relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []int64{1, 2, 3}, pub.ids)
+
+	// опубликованные события повторно не отправляются
+	published, err = relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	pub := NewWriterPublisher(&buf)
+
+	err := pub.Publish(context.Background(), entity.OutboxEvent{
+		ID:      7,
+		Type:    entity.EventUserDeleted,
+		Payload: json.RawMessage(`{"username":"bob"}`),
+	})
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, float64(7), got["id"])
+	assert.Equal(t, entity.EventUserDeleted, got["type"])
+	assert.Equal(t, map[string]interface{}{"username": "bob"}, got["payload"])
+
+	// в stdout события идут вперемешку с логами, поэтому у них префикс
+	buf.Reset()
+	pub = &WriterPublisher{w: &buf, prefix: StdoutPrefix}
+	require.NoError(t, pub.Publish(context.Background(), entity.OutboxEvent{ID: 8, Type: entity.EventUserDeleted}))
+	line, ok := bytes.CutPrefix(buf.Bytes(), []byte(StdoutPrefix))
+	require.True(t, ok)
+	require.NoError(t, json.Unmarshal(line, &got))
+	assert.Equal(t, float64(8), got["id"])
+}
+
+type memStore struct {
+	events []entity.OutboxEvent
+}
+
+func (s *memStore) PendingOutboxEvents(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	var pending []entity.OutboxEvent
+	for _, e := range s.events {
+		if e.PublishedAt == nil && len(pending) < limit {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+func (s *memStore) MarkOutboxEventPublished(ctx context.Context, id int64, at time.Time) error {
+	s.events[id-1].PublishedAt = &at
+	return nil
+}
+
+type flakyPublisher struct {
+	failOn int64
+	ids    []int64
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, e entity.OutboxEvent) error {
+	if e.ID == p.failOn {
+		return errors.New("bus is down")
+	}
+	p.ids = append(p.ids, e.ID)
+	return nil
+}
@@ -0,0 +1,65 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Build converts the public dump ("<SHA1 HEX>:<count>" per line, ordered by hash)
+// into the index format. Entries seen less than minCount times are dropped.
+// Returns number of written records.
+func Build(r io.Reader, w io.Writer, minCount int) (int, error) {
+	out := bufio.NewWriter(w)
+	if _, err := out.WriteString(magic); err != nil {
+		return 0, err
+	}
+
+	var (
+		prev    []byte
+		record  [recordSize]byte
+		written int
+	)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		hexHash, countStr, found := strings.Cut(text, ":")
+		if !found || len(hexHash) != hex.EncodedLen(hashSize) {
+			return written, fmt.Errorf("line %d: expected <sha1>:<count>", line)
+		}
+		if _, err := hex.Decode(record[:hashSize], []byte(hexHash)); err != nil {
+			return written, fmt.Errorf("line %d: %w", line, err)
+		}
+		count, err := strconv.ParseUint(countStr, 10, 64)
+		if err != nil {
+			return written, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if prev != nil && bytes.Compare(prev, record[:hashSize]) >= 0 {
+			return written, fmt.Errorf("line %d: input must be sorted by hash, use the ordered-by-hash dump", line)
+		}
+		prev = append(prev[:0], record[:hashSize]...)
+
+		if count < uint64(minCount) {
+			continue
+		}
+		binary.BigEndian.PutUint32(record[hashSize:], uint32(min(count, math.MaxUint32)))
+		if _, err := out.Write(record[:]); err != nil {
+			return written, err
+		}
+		written++
+	}
+	if err := scanner.Err(); err != nil {
+		return written, err
+	}
+	return written, out.Flush()
+}
@@ -0,0 +1,96 @@
+// Package breach looks up passwords in a local copy of the Have I Been Pwned SHA-1 dump.
+//
+// Index file layout: 8 byte magic followed by fixed size records sorted by hash,
+// each record is 20 bytes of SHA-1 and big-endian uint32 number of occurrences.
+// Lookup is a binary search with ReadAt, the file is never loaded into memory.
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+const (
+	magic      = "BREACH1\n"
+	hashSize   = sha1.Size
+	recordSize = hashSize + 4
+)
+
+var ErrInvalidIndex = errors.New("invalid breach index")
+
+type Index struct {
+	f       *os.File
+	records int64
+}
+
+func Open(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(f, header); err != nil || string(header) != magic {
+		f.Close()
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidIndex)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	body := st.Size() - int64(len(magic))
+	if body%recordSize != 0 {
+		f.Close()
+		return nil, fmt.Errorf("%w: truncated", ErrInvalidIndex)
+	}
+
+	return &Index{f: f, records: body / recordSize}, nil
+}
+
+func (ix *Index) Close() error {
+	return ix.f.Close()
+}
+
+// Count returns how many times password appeared in breaches, 0 if it's not known.
+func (ix *Index) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	return ix.CountHash(sum)
+}
+
+func (ix *Index) CountHash(sum [hashSize]byte) (int, error) {
+	var (
+		record  [recordSize]byte
+		readErr error
+	)
+	i := sort.Search(int(ix.records), func(i int) bool {
+		if readErr != nil {
+			return true
+		}
+		if _, err := ix.f.ReadAt(record[:], int64(len(magic))+int64(i)*recordSize); err != nil {
+			readErr = err
+			return true
+		}
+		return bytes.Compare(record[:hashSize], sum[:]) >= 0
+	})
+	if readErr != nil {
+		return 0, readErr
+	}
+	if int64(i) == ix.records {
+		return 0, nil
+	}
+
+	if _, err := ix.f.ReadAt(record[:], int64(len(magic))+int64(i)*recordSize); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(record[:hashSize], sum[:]) {
+		return 0, nil
+	}
+	return int(binary.BigEndian.Uint32(record[hashSize:])), nil
+}
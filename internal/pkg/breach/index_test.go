@@ -0,0 +1,76 @@
+package breach
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dump(counts map[string]int) string {
+	lines := make([]string, 0, len(counts))
+	for password, count := range counts {
+		sum := sha1.Sum([]byte(password))
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(sum[:])), count))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\r\n")
+}
+
+func TestBuildAndLookup(t *testing.T) {
+	counts := map[string]int{"password": 100, "123456": 50, "qwerty": 7, "letmein": 1}
+	for i := 0; i < 200; i++ {
+		counts[fmt.Sprintf("filler%d", i)] = 2
+	}
+
+	path := filepath.Join(t.TempDir(), "pwned.idx")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	n, err := Build(strings.NewReader(dump(counts)), f, 2)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, len(counts)-1, n, "entries below min count are dropped")
+
+	index, err := Open(path)
+	require.NoError(t, err)
+	defer index.Close()
+
+	tests := []struct {
+		password string
+		count    int
+	}{
+		{"password", 100},
+		{"qwerty", 7},
+		{"filler0", 2},
+		{"filler199", 2},
+		{"letmein", 0},
+		{"correct horse battery staple", 0},
+	}
+	for _, tt := range tests {
+		count, err := index.Count(tt.password)
+		require.NoError(t, err)
+		assert.Equal(t, tt.count, count, tt.password)
+	}
+}
+
+// Неотсортированный дамп не подходит для бинарного поиска
+func TestBuildRejectsUnsorted(t *testing.T) {
+	in := "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1\n0000000000000000000000000000000000000000:1\n"
+	_, err := Build(strings.NewReader(in), &strings.Builder{}, 1)
+	assert.ErrorContains(t, err, "sorted")
+}
+
+func TestOpenRejectsForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.txt")
+	require.NoError(t, os.WriteFile(path, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:1\n"), 0o644))
+
+	_, err := Open(path)
+	assert.ErrorIs(t, err, ErrInvalidIndex)
+}
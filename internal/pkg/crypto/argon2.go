@@ -0,0 +1,139 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var ErrInvalidHash = errors.New("invalid password hash")
+
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follow OWASP recommendation for argon2id.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// MaxArgon2Params - upper bounds of parameters, both configured and read from stored hashes.
+// A crafted or imported hash mustn't make a single login take gigabytes of memory or minutes of CPU.
+var MaxArgon2Params = Argon2Params{
+	Memory:      256 * 1024,
+	Iterations:  16,
+	Parallelism: 16,
+	SaltLength:  64,
+	KeyLength:   128,
+}
+
+// Validate checks parameters are non-zero and within MaxArgon2Params.
+func (p Argon2Params) Validate() error {
+	max := MaxArgon2Params
+	if p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 || p.KeyLength == 0 {
+		return errors.New("argon2id parameters must be positive")
+	}
+	if p.Memory > max.Memory || p.Iterations > max.Iterations || p.Parallelism > max.Parallelism ||
+		p.SaltLength > max.SaltLength || p.KeyLength > max.KeyLength {
+		return fmt.Errorf("argon2id parameters m=%d,t=%d,p=%d exceed the limits m=%d,t=%d,p=%d",
+			p.Memory, p.Iterations, p.Parallelism, max.Memory, max.Iterations, max.Parallelism)
+	}
+	return nil
+}
+
+// Argon2idHasher produces PHC strings: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+type Argon2idHasher struct {
+	Params Argon2Params
+}
+
+func NewArgon2idHasher(p Argon2Params) Argon2idHasher {
+	return Argon2idHasher{Params: p}
+}
+
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.Params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Params.Iterations, h.Params.Memory, h.Params.Parallelism, h.Params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.Params.Memory, h.Params.Iterations, h.Params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h Argon2idHasher) Verify(encoded, password string) (bool, error) {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (h Argon2idHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (h Argon2idHasher) NeedsRehash(encoded string) bool {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return p.Memory < h.Params.Memory ||
+		p.Iterations < h.Params.Iterations ||
+		p.Parallelism < h.Params.Parallelism ||
+		uint32(len(salt)) < h.Params.SaltLength ||
+		uint32(len(key)) < h.Params.KeyLength
+}
+
+func decodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	if version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidHash, version)
+	}
+
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	if err := p.Validate(); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	return p, salt, key, nil
+}
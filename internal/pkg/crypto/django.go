@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// DjangoVerifier checks hashes stored by Django's auth app:
+//
+//	pbkdf2_sha256$<iterations>$<salt>$<hash>
+//	pbkdf2_sha1$<iterations>$<salt>$<hash>
+//	scrypt$<N>$<salt>$<r>$<p>$<hash>
+//	argon2$argon2id$v=19$m=..,t=..,p=..$<salt>$<hash>
+//
+// Django uses the salt string as is, hashes are standard base64.
+type DjangoVerifier struct{}
+
+var djangoDigests = map[string]func() hash.Hash{
+	"pbkdf2_sha256": sha256.New,
+	"pbkdf2_sha1":   sha1.New,
+}
+
+func (DjangoVerifier) Identifies(encoded string) bool {
+	algorithm, _, found := strings.Cut(encoded, "$")
+	if !found {
+		return false
+	}
+	_, ok := djangoDigests[algorithm]
+	return ok || algorithm == "scrypt" || algorithm == "argon2"
+}
+
+func (v DjangoVerifier) Verify(encoded, password string) (bool, error) {
+	algorithm, rest, _ := strings.Cut(encoded, "$")
+	switch algorithm {
+	case "scrypt":
+		return v.verifyScrypt(rest, password)
+	case "argon2":
+		// the rest is a regular PHC string
+		return Argon2idHasher{}.Verify("$"+rest, password)
+	}
+
+	digest, ok := djangoDigests[algorithm]
+	if !ok {
+		return false, ErrInvalidHash
+	}
+	parts := strings.Split(rest, "$")
+	if len(parts) != 3 {
+		return false, ErrInvalidHash
+	}
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, ErrInvalidHash
+	}
+	key, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, ErrInvalidHash
+	}
+	return pbkdf2Equal(password, []byte(parts[1]), iterations, key, digest)
+}
+
+func (DjangoVerifier) verifyScrypt(rest, password string) (bool, error) {
+	// N, salt, r, p, hash
+	parts := strings.Split(rest, "$")
+	if len(parts) != 5 {
+		return false, ErrInvalidHash
+	}
+	var params [3]int
+	for i, s := range []string{parts[0], parts[2], parts[3]} {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return false, ErrInvalidHash
+		}
+		params[i] = n
+	}
+	key, err := base64.StdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return false, ErrInvalidHash
+	}
+	return scryptEqual(password, []byte(parts[1]), params[0], params[1], params[2], key)
+}
@@ -1,25 +1,109 @@
 package crypto
 
-import "golang.org/x/crypto/bcrypt"
+import "errors"
 
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// Verifier checks passwords against hashes of one format.
+type Verifier interface {
+	Verify(encoded, password string) (bool, error)
+	// Identifies tells whether encoded hash was produced by this algorithm.
+	Identifies(encoded string) bool
+}
+
+// Hasher - algorithm new hashes can be made with.
+type Hasher interface {
+	Verifier
+	Hash(password string) (string, error)
+	// NeedsRehash reports hashes made with parameters weaker than the configured ones.
+	NeedsRehash(encoded string) bool
+}
+
+// PasswordHasher hashes new passwords with the primary algorithm and still
+// accepts hashes made by the legacy ones, e.g. imported from other systems.
 type PasswordHasher struct {
+	primary Hasher
+	legacy  []Verifier
+
+	// optional, see WithPeppers
+	pepperVersion string
+	peppers       map[string][]byte
 }
 
-func NewPasswordHasher() PasswordHasher {
-	return PasswordHasher{}
+func NewPasswordHasher(primary Hasher, legacy ...Verifier) PasswordHasher {
+	return PasswordHasher{primary: primary, legacy: legacy}
 }
 
 func (ph PasswordHasher) HashPassword(password string) ([]byte, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if ph.pepperVersion == "" {
+		encoded, err := ph.primary.Hash(password)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(encoded), nil
+	}
+
+	encoded, err := ph.primary.Hash(pepper(ph.peppers[ph.pepperVersion], password))
 	if err != nil {
 		return nil, err
 	}
-	return hashedPassword, nil
+	return []byte(pepperedPrefix + ph.pepperVersion + encoded), nil
 }
 
 func (ph PasswordHasher) ComparePasswords(fromUser, fromDB string) bool {
-	if err := bcrypt.CompareHashAndPassword([]byte(fromUser), []byte(fromDB)); err != nil {
+	encoded, password := fromUser, fromDB
+	if version, inner, ok := splitPeppered(encoded); ok {
+		key, known := ph.peppers[version]
+		if !known {
+			return false
+		}
+		encoded, password = inner, pepper(key, password)
+	}
+
+	h, err := ph.verifierFor(encoded)
+	if err != nil {
 		return false
 	}
-	return true
+	ok, err := h.Verify(encoded, password)
+	return err == nil && ok
+}
+
+// NeedsRehash is true when hash should be replaced by one made with the primary algorithm
+// and the current pepper.
+func (ph PasswordHasher) NeedsRehash(encoded string) bool {
+	version, inner, peppered := splitPeppered(encoded)
+	if peppered != (ph.pepperVersion != "") || version != ph.pepperVersion {
+		return true
+	}
+	if peppered {
+		encoded = inner
+	}
+	if !ph.primary.Identifies(encoded) {
+		return true
+	}
+	return ph.primary.NeedsRehash(encoded)
+}
+
+// Supports reports whether hashes of this format can be verified.
+func (ph PasswordHasher) Supports(encoded string) bool {
+	if version, inner, ok := splitPeppered(encoded); ok {
+		if _, known := ph.peppers[version]; !known {
+			return false
+		}
+		encoded = inner
+	}
+	_, err := ph.verifierFor(encoded)
+	return err == nil
+}
+
+func (ph PasswordHasher) verifierFor(encoded string) (Verifier, error) {
+	if ph.primary.Identifies(encoded) {
+		return ph.primary, nil
+	}
+	for _, h := range ph.legacy {
+		if h.Identifies(encoded) {
+			return h, nil
+		}
+	}
+	return nil, ErrUnknownHashFormat
 }
@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// параметры поменьше, чтобы тесты шли быстро
+var testArgon2Params = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2idHasher(t *testing.T) {
+	h := NewArgon2idHasher(testArgon2Params)
+
+	encoded, err := h.Hash("password")
+	require.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=1024,t=1,p=1\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+$`, encoded)
+
+	ok, err := h.Verify(encoded, "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(encoded, "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = h.Verify("$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5", "password")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+	// чужой хеш не может заставить логин потратить 4 ГиБ памяти
+	_, err = h.Verify("$argon2id$v=19$m=4194304,t=1,p=1$c2FsdA$a2V5", "password")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+
+	assert.False(t, h.NeedsRehash(encoded))
+	stronger := testArgon2Params
+	stronger.Iterations = 2
+	assert.True(t, NewArgon2idHasher(stronger).NeedsRehash(encoded))
+}
+
+// Старые bcrypt-хеши принимаются и помечаются для перехеширования
+func TestPasswordHasherLegacy(t *testing.T) {
+	bcrypt := NewBcryptHasher(4)
+	legacy, err := bcrypt.Hash("password")
+	require.NoError(t, err)
+
+	ph := NewPasswordHasher(NewArgon2idHasher(testArgon2Params), bcrypt)
+
+	assert.True(t, ph.ComparePasswords(legacy, "password"))
+	assert.False(t, ph.ComparePasswords(legacy, "wrong"))
+	assert.True(t, ph.NeedsRehash(legacy))
+
+	hashed, err := ph.HashPassword("password")
+	require.NoError(t, err)
+	assert.True(t, ph.ComparePasswords(string(hashed), "password"))
+	assert.False(t, ph.NeedsRehash(string(hashed)))
+
+	assert.False(t, ph.ComparePasswords("plaintext", "plaintext"))
+}
+
+func TestBcryptNeedsRehash(t *testing.T) {
+	cheap, err := NewBcryptHasher(4).Hash("password")
+	require.NoError(t, err)
+
+	assert.False(t, NewBcryptHasher(4).NeedsRehash(cheap))
+	assert.True(t, NewBcryptHasher(5).NeedsRehash(cheap))
+}
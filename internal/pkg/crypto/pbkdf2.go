@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ab64 - passlib "adapted base64": standard alphabet with '.' instead of '+', no padding
+var ab64 = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").WithPadding(base64.NoPadding)
+
+// limits of imported hashes, a crafted one mustn't make a single login take minutes of CPU.
+// Django 5 uses 1M iterations, passlib defaults are far lower.
+const (
+	maxPBKDF2Iterations = 2_000_000
+	maxKeyLength        = 128
+)
+
+var pbkdf2Digests = map[string]func() hash.Hash{
+	"pbkdf2":        sha1.New,
+	"pbkdf2-sha256": sha256.New,
+	"pbkdf2-sha512": sha512.New,
+}
+
+// PBKDF2Verifier checks passlib hashes: $pbkdf2-sha256$<rounds>$<salt>$<checksum>
+type PBKDF2Verifier struct{}
+
+func (PBKDF2Verifier) Identifies(encoded string) bool {
+	parts := strings.SplitN(encoded, "$", 3)
+	if len(parts) < 3 || parts[0] != "" {
+		return false
+	}
+	_, ok := pbkdf2Digests[parts[1]]
+	return ok
+}
+
+func (PBKDF2Verifier) Verify(encoded, password string) (bool, error) {
+	// "", "pbkdf2-sha256", rounds, salt, checksum
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 {
+		return false, ErrInvalidHash
+	}
+	digest, ok := pbkdf2Digests[parts[1]]
+	if !ok {
+		return false, ErrInvalidHash
+	}
+	rounds, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return false, ErrInvalidHash
+	}
+	salt, err := ab64.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	key, err := ab64.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrInvalidHash
+	}
+	return pbkdf2Equal(password, salt, rounds, key, digest)
+}
+
+func pbkdf2Equal(password string, salt []byte, iterations int, key []byte, digest func() hash.Hash) (bool, error) {
+	if iterations < 1 || iterations > maxPBKDF2Iterations || len(key) == 0 || len(key) > maxKeyLength {
+		return false, ErrInvalidHash
+	}
+	other := pbkdf2.Key([]byte(password), salt, iterations, len(key), digest)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// pepperedPrefix marks hashes of HMAC(pepper, password): $pepper$<version><inner hash>,
+// e.g. $pepper$2$argon2id$v=19$...
+const pepperedPrefix = "$pepper$"
+
+const minPepperLength = 16
+
+var ErrInvalidPepper = errors.New("invalid pepper")
+
+// Pepper - secret kept outside of the database. Version is stored with every hash,
+// so old peppers keep working until users log in and get rehashed with the current one.
+type Pepper struct {
+	Version string
+	Key     []byte
+}
+
+// WithPeppers makes new hashes with current pepper, previous are only used for verification.
+// Zero current turns peppering of new hashes off while hashes made with previous keep working.
+func (ph PasswordHasher) WithPeppers(current Pepper, previous ...Pepper) (PasswordHasher, error) {
+	peppers := make(map[string][]byte, len(previous)+1)
+	all := previous
+	if current.Version != "" || current.Key != nil {
+		all = append([]Pepper{current}, previous...)
+	}
+	for _, p := range all {
+		if p.Version == "" || strings.Contains(p.Version, "$") {
+			return PasswordHasher{}, fmt.Errorf("%w: bad version %q", ErrInvalidPepper, p.Version)
+		}
+		if len(p.Key) < minPepperLength {
+			return PasswordHasher{}, fmt.Errorf("%w: version %s is shorter than %d bytes", ErrInvalidPepper, p.Version, minPepperLength)
+		}
+		if _, ok := peppers[p.Version]; ok {
+			return PasswordHasher{}, fmt.Errorf("%w: duplicate version %s", ErrInvalidPepper, p.Version)
+		}
+		peppers[p.Version] = p.Key
+	}
+	ph.pepperVersion = current.Version
+	ph.peppers = peppers
+	return ph, nil
+}
+
+// pepper returns password transformed with the key of given version.
+// The result is base64 so it's safe for bcrypt (no NUL bytes, under 72 bytes).
+func pepper(key []byte, password string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// splitPeppered returns pepper version and inner hash; ok is false for unpeppered hashes.
+func splitPeppered(encoded string) (version, inner string, ok bool) {
+	rest, found := strings.CutPrefix(encoded, pepperedPrefix)
+	if !found {
+		return "", "", false
+	}
+	version, inner, found = strings.Cut(rest, "$")
+	if !found {
+		return "", "", false
+	}
+	return version, "$" + inner, true
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Смена версии перца: старые хеши проверяются и помечаются для перехеширования
+func TestPepperRotation(t *testing.T) {
+	base := NewPasswordHasher(NewArgon2idHasher(testArgon2Params), NewBcryptHasher(4))
+	v1 := Pepper{Version: "1", Key: []byte("0123456789abcdef-first")}
+	v2 := Pepper{Version: "2", Key: []byte("0123456789abcdef-second")}
+
+	first, err := base.WithPeppers(v1)
+	require.NoError(t, err)
+	hashed, err := first.HashPassword("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(hashed), "$pepper$1$argon2id$"))
+	assert.True(t, first.ComparePasswords(string(hashed), "password"))
+	assert.False(t, first.NeedsRehash(string(hashed)))
+
+	// без перца хеш не проверить - в этом и смысл
+	assert.False(t, base.ComparePasswords(string(hashed), "password"))
+
+	rotated, err := base.WithPeppers(v2, v1)
+	require.NoError(t, err)
+	assert.True(t, rotated.ComparePasswords(string(hashed), "password"))
+	assert.False(t, rotated.ComparePasswords(string(hashed), "wrong"))
+	assert.True(t, rotated.NeedsRehash(string(hashed)))
+
+	// хеши без перца тоже принимаются до первого логина
+	plain, err := base.HashPassword("password")
+	require.NoError(t, err)
+	assert.True(t, rotated.ComparePasswords(string(plain), "password"))
+	assert.True(t, rotated.NeedsRehash(string(plain)))
+
+	// перец выключен для новых хешей, но старые ключи продолжают проверять пароли
+	disabled, err := base.WithPeppers(Pepper{}, v1)
+	require.NoError(t, err)
+	assert.True(t, disabled.ComparePasswords(string(hashed), "password"))
+	assert.True(t, disabled.NeedsRehash(string(hashed)))
+	unpeppered, err := disabled.HashPassword("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(unpeppered), "$argon2id$"))
+	assert.False(t, disabled.NeedsRehash(string(unpeppered)))
+}
+
+func TestWithPeppersValidation(t *testing.T) {
+	base := NewPasswordHasher(NewArgon2idHasher(testArgon2Params))
+
+	_, err := base.WithPeppers(Pepper{Version: "1", Key: []byte("short")})
+	assert.ErrorIs(t, err, ErrInvalidPepper)
+
+	_, err = base.WithPeppers(Pepper{Version: "a$b", Key: []byte("0123456789abcdef")})
+	assert.ErrorIs(t, err, ErrInvalidPepper)
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// limits of imported scrypt hashes: memory is 128*N*r bytes, CPU grows with it times p
+const (
+	maxScryptMemory      = 256 << 20
+	maxScryptR           = 32
+	maxScryptParallelism = 16
+)
+
+// ScryptVerifier checks passlib hashes: $scrypt$ln=<log2 N>,r=<r>,p=<p>$<salt>$<checksum>
+type ScryptVerifier struct{}
+
+func (ScryptVerifier) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$scrypt$")
+}
+
+func (ScryptVerifier) Verify(encoded, password string) (bool, error) {
+	// "", "scrypt", params, salt, checksum
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return false, ErrInvalidHash
+	}
+	var ln, r, p int
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &ln, &r, &p); err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	if ln < 1 || ln > 30 {
+		return false, ErrInvalidHash
+	}
+	salt, err := ab64.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	key, err := ab64.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return false, ErrInvalidHash
+	}
+	return scryptEqual(password, salt, 1<<ln, r, p, key)
+}
+
+func scryptEqual(password string, salt []byte, n, r, p int, key []byte) (bool, error) {
+	if n < 2 || n > maxScryptMemory || r < 1 || r > maxScryptR || p < 1 || p > maxScryptParallelism ||
+		128*n*r > maxScryptMemory || len(key) == 0 || len(key) > maxKeyLength {
+		return false, ErrInvalidHash
+	}
+	other, err := scrypt.Key([]byte(password), salt, n, r, p, len(key))
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidHash, err)
+	}
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
@@ -0,0 +1,148 @@
+package crypto
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+)
+
+const (
+	sha512CryptPrefix        = "$6$"
+	sha512CryptRoundsPrefix  = "rounds="
+	sha512CryptDefaultRounds = 5000
+	sha512CryptMinRounds     = 1000
+	sha512CryptMaxRounds     = 999999999
+	// what we agree to compute, the format allows much more than a login can afford
+	sha512CryptAcceptedRounds = 5_000_000
+	sha512CryptMaxSalt        = 16
+	cryptAlphabet             = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// SHA512CryptVerifier checks glibc crypt(3) hashes: $6$[rounds=<n>$]<salt>$<hash>
+type SHA512CryptVerifier struct{}
+
+func (SHA512CryptVerifier) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, sha512CryptPrefix)
+}
+
+func (SHA512CryptVerifier) Verify(encoded, password string) (bool, error) {
+	rest := strings.TrimPrefix(encoded, sha512CryptPrefix)
+
+	rounds, roundsCustom := sha512CryptDefaultRounds, false
+	if strings.HasPrefix(rest, sha512CryptRoundsPrefix) {
+		value, after, found := strings.Cut(strings.TrimPrefix(rest, sha512CryptRoundsPrefix), "$")
+		if !found {
+			return false, ErrInvalidHash
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n > sha512CryptAcceptedRounds {
+			return false, ErrInvalidHash
+		}
+		rounds, roundsCustom, rest = min(max(n, sha512CryptMinRounds), sha512CryptMaxRounds), true, after
+	}
+
+	salt, _, found := strings.Cut(rest, "$")
+	if !found {
+		return false, ErrInvalidHash
+	}
+	if len(salt) > sha512CryptMaxSalt {
+		salt = salt[:sha512CryptMaxSalt]
+	}
+
+	expected := sha512Crypt([]byte(password), []byte(salt), rounds, roundsCustom)
+	return subtle.ConstantTimeCompare([]byte(encoded), []byte(expected)) == 1, nil
+}
+
+// sha512Crypt implements https://www.akkadia.org/drepper/SHA-crypt.txt
+func sha512Crypt(password, salt []byte, rounds int, roundsCustom bool) string {
+	alt := sha512.New()
+	alt.Write(password)
+	alt.Write(salt)
+	alt.Write(password)
+	altSum := alt.Sum(nil)
+
+	a := sha512.New()
+	a.Write(password)
+	a.Write(salt)
+	a.Write(repeatTo(altSum, len(password)))
+	for i := len(password); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(altSum)
+		} else {
+			a.Write(password)
+		}
+	}
+	sum := a.Sum(nil)
+
+	dp := sha512.New()
+	for range password {
+		dp.Write(password)
+	}
+	p := repeatTo(dp.Sum(nil), len(password))
+
+	ds := sha512.New()
+	for i := 0; i < 16+int(sum[0]); i++ {
+		ds.Write(salt)
+	}
+	s := repeatTo(ds.Sum(nil), len(salt))
+
+	for r := 0; r < rounds; r++ {
+		c := sha512.New()
+		if r&1 != 0 {
+			c.Write(p)
+		} else {
+			c.Write(sum)
+		}
+		if r%3 != 0 {
+			c.Write(s)
+		}
+		if r%7 != 0 {
+			c.Write(p)
+		}
+		if r&1 != 0 {
+			c.Write(sum)
+		} else {
+			c.Write(p)
+		}
+		sum = c.Sum(nil)
+	}
+
+	var b strings.Builder
+	b.WriteString(sha512CryptPrefix)
+	if roundsCustom {
+		b.WriteString(sha512CryptRoundsPrefix + strconv.Itoa(rounds) + "$")
+	}
+	b.Write(salt)
+	b.WriteByte('$')
+	// bytes are encoded in the permuted order defined by the spec
+	for i := 0; i < 21; i++ {
+		b64From24Bit(&b, sum[i], sum[(i+21)], sum[(i+42)], 4, i)
+	}
+	b64From24Bit(&b, 0, 0, sum[63], 2, -1)
+	return b.String()
+}
+
+// b64From24Bit writes n crypt base64 chars of three bytes. Triple i of SHA-512 output is
+// rotated by i%3 positions: (0,21,42), (22,43,1), (44,2,23)...
+func b64From24Bit(b *strings.Builder, b0, b1, b2 byte, n, i int) {
+	switch i % 3 {
+	case 1:
+		b0, b1, b2 = b1, b2, b0
+	case 2:
+		b0, b1, b2 = b2, b0, b1
+	}
+	w := uint(b0)<<16 | uint(b1)<<8 | uint(b2)
+	for ; n > 0; n-- {
+		b.WriteByte(cryptAlphabet[w&0x3f])
+		w >>= 6
+	}
+}
+
+func repeatTo(src []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		out = append(out, src[:min(len(src), n-len(out))]...)
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Хеши сгенерированы passlib/Django/glibc для пароля "password"
+func TestForeignVerifiers(t *testing.T) {
+	argon, err := NewArgon2idHasher(testArgon2Params).Hash("password")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		verifier Verifier
+		encoded  string
+	}{
+		{
+			name:     "passlib pbkdf2-sha256",
+			verifier: PBKDF2Verifier{},
+			encoded:  "$pbkdf2-sha256$1000$MDEyMzQ1Njc4OWFiY2RlZg$hRRjgXWkW8ResfIvBP99J/T4vkgEmMRV/0tJTOjR59I",
+		},
+		{
+			name:     "passlib scrypt",
+			verifier: ScryptVerifier{},
+			encoded:  "$scrypt$ln=4,r=8,p=1$MDEyMzQ1Njc4OWFiY2RlZg$jU.wVnnRO8xMJ6kk2pn2W1IFgOT9r8PK.dHZ.HH3bt4",
+		},
+		{
+			name:     "sha512-crypt",
+			verifier: SHA512CryptVerifier{},
+			encoded:  "$6$rounds=1000$saltsalt$Z/J9iYO1iE9xnr8JPQL57ZWsVRtVjrUv3CiWc/wKWseqXgSqn3HFYJ/Ng7YXa8XlLj.wpdAwHOJJzuGFqBBRa0",
+		},
+		{
+			name:     "django pbkdf2_sha256",
+			verifier: DjangoVerifier{},
+			encoded:  "pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+		},
+		{
+			name:     "django scrypt",
+			verifier: DjangoVerifier{},
+			encoded:  "scrypt$16$seasalt$8$1$f/UZ5ciwOTKz0E7+pB7/Luubwyl5lh+pWQERgX17rAQDbty25Tg3writ9hULN4OFKi/+pbO8b9tQEIS5AeeIXA==",
+		},
+		{
+			name:     "django argon2",
+			verifier: DjangoVerifier{},
+			encoded:  "argon2" + argon,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.verifier.Identifies(tt.encoded))
+
+			ok, err := tt.verifier.Verify(tt.encoded, "password")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = tt.verifier.Verify(tt.encoded, "wrong")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+// Параметры импортированных хешей ограничены, чтобы один логин не съел минуты CPU или гигабайты памяти
+func TestForeignVerifiersLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier Verifier
+		encoded  string
+	}{
+		{"passlib pbkdf2-sha256", PBKDF2Verifier{}, "$pbkdf2-sha256$1000000000$MDEyMzQ1Njc4OWFiY2RlZg$hRRjgXWkW8ResfIvBP99J/T4vkgEmMRV/0tJTOjR59I"},
+		{"passlib scrypt", ScryptVerifier{}, "$scrypt$ln=30,r=8,p=1$MDEyMzQ1Njc4OWFiY2RlZg$jU.wVnnRO8xMJ6kk2pn2W1IFgOT9r8PK.dHZ.HH3bt4"},
+		{"passlib scrypt parallelism", ScryptVerifier{}, "$scrypt$ln=4,r=8,p=100000$MDEyMzQ1Njc4OWFiY2RlZg$jU.wVnnRO8xMJ6kk2pn2W1IFgOT9r8PK.dHZ.HH3bt4"},
+		{"sha512-crypt", SHA512CryptVerifier{}, "$6$rounds=999999999$saltsalt$Z/J9iYO1iE9xnr8JPQL57ZWsVRtVjrUv3CiWc/wKWseqXgSqn3HFYJ/Ng7YXa8XlLj.wpdAwHOJJzuGFqBBRa0"},
+		{"django pbkdf2_sha256", DjangoVerifier{}, "pbkdf2_sha256$1000000000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c="},
+		{"django scrypt", DjangoVerifier{}, "scrypt$1073741824$seasalt$8$1$f/UZ5ciwOTKz0E7+pB7/Luubwyl5lh+pWQERgX17rAQDbty25Tg3writ9hULN4OFKi/+pbO8b9tQEIS5AeeIXA=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.verifier.Verify(tt.encoded, "password")
+			assert.ErrorIs(t, err, ErrInvalidHash)
+		})
+	}
+}
+
+// Тестовые векторы из спецификации SHA-crypt
+func TestSHA512Crypt(t *testing.T) {
+	vectors := []struct{ encoded, password string }{
+		{"$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1", "Hello world!"},
+		{"$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v.", "Hello world!"},
+		{"$6$rounds=1400$anotherlongsalts$POfYwTEok97VWcjxIiSOjiykti.o/pQs.wPvMxQ6Fm7I6IoYN3CmLs66x9t0oSwbtEW7o7UmJEiDwGqd8p4ur1", "a very much longer text to encrypt.  This one even stretches over morethan one line."},
+	}
+	for _, v := range vectors {
+		ok, err := SHA512CryptVerifier{}.Verify(v.encoded, v.password)
+		require.NoError(t, err)
+		assert.True(t, ok, v.encoded)
+	}
+}
+
+// Импортированные хеши принимаются и заменяются на argon2id при логине
+func TestPasswordHasherForeignFormats(t *testing.T) {
+	ph := NewPasswordHasher(NewArgon2idHasher(testArgon2Params), PBKDF2Verifier{}, DjangoVerifier{})
+
+	django := "pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c="
+	assert.True(t, ph.Supports(django))
+	assert.True(t, ph.ComparePasswords(django, "password"))
+	assert.True(t, ph.NeedsRehash(django))
+
+	assert.False(t, ph.Supports("$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl"))
+	assert.False(t, ph.Supports("md5$salt$hash"))
+}
@@ -0,0 +1,92 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms. PS256 uses the same RSA keys as RS256, so it has to be chosen explicitly.
+const (
+	AlgEdDSA = "EdDSA"
+	AlgRS256 = "RS256"
+	AlgPS256 = "PS256"
+	AlgES256 = "ES256"
+)
+
+func signingMethod(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	case AlgRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgPS256:
+		return jwt.SigningMethodPS256, nil
+	case AlgES256:
+		return jwt.SigningMethodES256, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrKeyParsing, alg)
+}
+
+// defaultAlgorithm picks algorithm by key type when it's not configured.
+func defaultAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		return AlgEdDSA, nil
+	case *rsa.PublicKey:
+		return AlgRS256, nil
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return AlgES256, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unsupported key type %T", ErrKeyParsing, pub)
+}
+
+// checkAlgorithm makes sure the key can be used with alg.
+func checkAlgorithm(alg string, pub crypto.PublicKey) error {
+	ok := false
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		ok = alg == AlgEdDSA
+	case *rsa.PublicKey:
+		// shorter keys are rejected by most JWT libraries anyway
+		ok = (alg == AlgRS256 || alg == AlgPS256) && k.N.BitLen() >= 2048
+	case *ecdsa.PublicKey:
+		ok = alg == AlgES256 && k.Curve == elliptic.P256()
+	}
+	if !ok {
+		return fmt.Errorf("%w: %T key can't be used with %s", ErrKeyParsing, pub, alg)
+	}
+	return nil
+}
+
+// ParsePublicKey accepts PKIX ("PUBLIC KEY") and PKCS#1 ("RSA PUBLIC KEY") PEM blocks.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrKeyParsing
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, ErrKeyParsing
+		}
+		return pub, nil
+	case "RSA PUBLIC KEY":
+		pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, ErrKeyParsing
+		}
+		return pub, nil
+	}
+	return nil, ErrKeyParsing
+}
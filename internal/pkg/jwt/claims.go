@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Actor - RFC 8693 "act" claim, identifies the party acting on behalf of the subject.
+// In delegation chains earlier actors are nested, the outermost one is the current caller.
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// Claims - content of access tokens. Build them with NewClaims and With* methods,
+// IssueToken fills issuer, issue time, expiration and jti.
+type Claims struct {
+	// organization the token is scoped to, empty for global tokens
+	Tenant string `json:"tenant,omitempty"`
+	Role   string `json:"role,omitempty"`
+	// space separated, as in RFC 9068
+	Scope string `json:"scope,omitempty"`
+	// set for impersonation and delegation tokens
+	Actor *Actor `json:"act,omitempty"`
+	jwt.RegisteredClaims
+
+	// arbitrary claims, serialized next to the standard ones
+	Custom map[string]any `json:"-"`
+
+	// lifetime overriding manager default
+	ttl time.Duration
+}
+
+// names Custom can't take: registered claims, fields of Claims and purpose of one-time tokens
+var reservedClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"tenant": true, "role": true, "scope": true, "act": true, "purpose": true,
+}
+
+func NewClaims(subject string) Claims {
+	return Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}}
+}
+
+func (c Claims) WithTenant(tenant string) Claims {
+	c.Tenant = tenant
+	return c
+}
+
+func (c Claims) WithRole(role string) Claims {
+	c.Role = role
+	return c
+}
+
+func (c Claims) WithScopes(scopes ...string) Claims {
+	c.Scope = strings.Join(scopes, " ")
+	return c
+}
+
+func (c Claims) WithAudience(audience ...string) Claims {
+	c.Audience = audience
+	return c
+}
+
+// WithActor marks the token as issued to actor acting on behalf of the subject.
+func (c Claims) WithActor(actor string) Claims {
+	c.Actor = &Actor{Subject: actor}
+	return c
+}
+
+// WithDelegation is WithActor keeping prior actors of the delegation chain nested.
+func (c Claims) WithDelegation(actor string, prior *Actor) Claims {
+	c.Actor = &Actor{Subject: actor, Actor: prior}
+	return c
+}
+
+// WithNotBefore makes the token valid only from t.
+func (c Claims) WithNotBefore(t time.Time) Claims {
+	c.NotBefore = jwt.NewNumericDate(t)
+	return c
+}
+
+// WithTTL overrides default token lifetime of the manager.
+func (c Claims) WithTTL(ttl time.Duration) Claims {
+	c.ttl = ttl
+	return c
+}
+
+// WithClaim adds custom claim, reserved names make IssueToken fail.
+func (c Claims) WithClaim(name string, value any) Claims {
+	custom := make(map[string]any, len(c.Custom)+1)
+	for k, v := range c.Custom {
+		custom[k] = v
+	}
+	custom[name] = value
+	c.Custom = custom
+	return c
+}
+
+// Scopes splits scope claim.
+func (c Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+func (c Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ActorSubject - real caller of impersonation and delegation tokens, empty otherwise.
+func (c Claims) ActorSubject() string {
+	if c.Actor == nil {
+		return ""
+	}
+	return c.Actor.Subject
+}
+
+// has reports whether claim is present in the token.
+func (c Claims) has(name string) bool {
+	switch name {
+	case "iss":
+		return c.Issuer != ""
+	case "sub":
+		return c.Subject != ""
+	case "aud":
+		return len(c.Audience) > 0
+	case "exp":
+		return c.ExpiresAt != nil
+	case "nbf":
+		return c.NotBefore != nil
+	case "iat":
+		return c.IssuedAt != nil
+	case "jti":
+		return c.ID != ""
+	case "tenant":
+		return c.Tenant != ""
+	case "role":
+		return c.Role != ""
+	case "scope":
+		return c.Scope != ""
+	case "act":
+		return c.Actor != nil
+	}
+	_, ok := c.Custom[name]
+	return ok
+}
+
+func (c Claims) validateCustom() error {
+	for name := range c.Custom {
+		if reservedClaims[name] {
+			return fmt.Errorf("claim %q is reserved", name)
+		}
+	}
+	return nil
+}
+
+// claimsJSON has no methods, so it's encoded as a plain struct.
+type claimsJSON Claims
+
+func (c Claims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(claimsJSON(c))
+	if err != nil || len(c.Custom) == 0 {
+		return data, err
+	}
+
+	merged := map[string]any{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Custom {
+		if reservedClaims[name] {
+			return nil, fmt.Errorf("claim %q is reserved", name)
+		}
+		merged[name] = value
+	}
+	return json.Marshal(merged)
+}
+
+// UnmarshalJSON collects claims unknown to Claims into Custom.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var known claimsJSON
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	*c = Claims(known)
+	for name, value := range all {
+		if reservedClaims[name] && name != "purpose" {
+			continue
+		}
+		if c.Custom == nil {
+			c.Custom = map[string]any{}
+		}
+		c.Custom[name] = value
+	}
+	return nil
+}
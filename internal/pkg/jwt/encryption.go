@@ -0,0 +1,127 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/bogatyr285/auth-go/internal/pkg/signer"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// Encryption - nested JWT mode: tokens are signed and then encrypted for the recipient key
+// (ECDH-ES for EC keys, RSA-OAEP-256 for RSA ones, dir or A256KW for a shared []byte key) with A256GCM,
+// so proxies in between can't read the claims.
+type Encryption struct {
+	KeyID     string
+	Recipient crypto.PublicKey
+	// private part of the recipient key, nil - manager issues encrypted tokens but can't verify them
+	Decrypter crypto.PrivateKey
+	// plain signed tokens are rejected by VerifyToken
+	Required bool
+
+	algorithm jose.KeyAlgorithm
+}
+
+// ParseEncryptionKey reads PEM encoded recipient key, privateKeyPEM may be empty.
+func ParseEncryptionKey(id string, publicKeyPEM, privateKeyPEM []byte) (Encryption, error) {
+	pub, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return Encryption{}, err
+	}
+	enc := Encryption{KeyID: id, Recipient: pub}
+	if enc.algorithm, err = keyAlgorithm(pub); err != nil {
+		return Encryption{}, err
+	}
+	if len(privateKeyPEM) == 0 {
+		return enc, nil
+	}
+
+	priv, err := signer.ParsePEM(privateKeyPEM)
+	if err != nil {
+		return Encryption{}, fmt.Errorf("%w: %s", ErrKeyParsing, err)
+	}
+	if _, err := keyAlgorithm(priv.Public()); err != nil {
+		return Encryption{}, err
+	}
+	if public, ok := priv.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !public.Equal(pub) {
+		return Encryption{}, fmt.Errorf("%w: encryption private key doesn't match the public key", ErrKeyParsing)
+	}
+	enc.Decrypter = priv
+	return enc, nil
+}
+
+// NewSharedEncryption uses 256-bit key known to both sides: dir encrypts the content with it directly,
+// A256KW wraps a random content key with it.
+func NewSharedEncryption(id, algorithm string, key []byte) (Encryption, error) {
+	alg := jose.KeyAlgorithm(algorithm)
+	if alg != jose.DIRECT && alg != jose.A256KW {
+		return Encryption{}, fmt.Errorf("%w: unsupported encryption algorithm %q", ErrKeyParsing, algorithm)
+	}
+	if len(key) != 32 {
+		return Encryption{}, fmt.Errorf("%w: shared encryption key must be 32 bytes, got %d", ErrKeyParsing, len(key))
+	}
+	return Encryption{KeyID: id, Recipient: key, Decrypter: key, algorithm: alg}, nil
+}
+
+func keyAlgorithm(pub crypto.PublicKey) (jose.KeyAlgorithm, error) {
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		return jose.ECDH_ES, nil
+	case *rsa.PublicKey:
+		return jose.RSA_OAEP_256, nil
+	}
+	return "", fmt.Errorf("%w: %T key can't be used for encryption", ErrKeyParsing, pub)
+}
+
+// WithEncryption turns on nested JWT mode for access tokens.
+func WithEncryption(e Encryption) Option {
+	return func(j *JWTManager) {
+		if e.algorithm == "" {
+			e.algorithm, _ = keyAlgorithm(e.Recipient)
+		}
+		j.encryption = &e
+	}
+}
+
+func (j *JWTManager) encrypt(signed string) (string, error) {
+	encrypter, err := jose.NewEncrypter(jose.A256GCM,
+		jose.Recipient{Algorithm: j.encryption.algorithm, Key: j.encryption.Recipient, KeyID: j.encryption.KeyID},
+		(&jose.EncrypterOptions{}).WithType("JWT").WithContentType("JWT"))
+	if err != nil {
+		return "", err
+	}
+	object, err := encrypter.Encrypt([]byte(signed))
+	if err != nil {
+		return "", err
+	}
+	return object.CompactSerialize()
+}
+
+// decrypt unwraps nested token, signed tokens are returned as is unless encryption is required.
+func (j *JWTManager) decrypt(token string) (string, error) {
+	if strings.Count(token, ".") != 4 {
+		if j.encryption != nil && j.encryption.Required {
+			return "", fmt.Errorf("token is not encrypted")
+		}
+		return token, nil
+	}
+	if j.encryption == nil || j.encryption.Decrypter == nil {
+		return "", fmt.Errorf("encrypted tokens are not accepted")
+	}
+
+	object, err := jose.ParseEncryptedCompact(token, []jose.KeyAlgorithm{j.encryption.algorithm}, []jose.ContentEncryption{jose.A256GCM})
+	if err != nil {
+		return "", err
+	}
+	if object.Header.ExtraHeaders[jose.HeaderContentType] != "JWT" {
+		return "", fmt.Errorf("encrypted content is not a JWT")
+	}
+	signed, err := object.Decrypt(j.encryption.Decrypter)
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
@@ -0,0 +1,51 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK - public key in RFC 7517 format, fields not used by key type are empty.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// PublicKeys returns keys consumers need to verify tokens: the active one and those still in their overlap window.
+// During migration to another algorithm keys of both are published, so consumers can switch at their own pace.
+func (j *JWTManager) PublicKeys() []JWK {
+	keys := j.keys.usableKeys(j.now())
+	jwks := make([]JWK, 0, len(keys))
+	for _, k := range keys {
+		jwk := JWK{Kid: k.ID, Alg: k.Algorithm, Use: "sig"}
+		switch pub := k.PublicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty, jwk.Crv, jwk.X = "OKP", "Ed25519", b64(pub)
+		case *rsa.PublicKey:
+			jwk.Kty, jwk.N, jwk.E = "RSA", b64(pub.N.Bytes()), b64(big.NewInt(int64(pub.E)).Bytes())
+		case *ecdsa.PublicKey:
+			// coordinates are padded to the curve size
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			jwk.Kty, jwk.Crv = "EC", pub.Curve.Params().Name
+			jwk.X, jwk.Y = b64(pub.X.FillBytes(make([]byte, size))), b64(pub.Y.FillBytes(make([]byte, size)))
+		default:
+			continue
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -1,9 +1,6 @@
 package jwt
 
 import (
-	"crypto/ed25519"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -20,77 +17,176 @@ var (
 )
 
 type JWTManager struct {
-	issuer     string
-	expiresIn  time.Duration
-	publicKey  ed25519.PublicKey
-	privateKey ed25519.PrivateKey
+	issuer    string
+	expiresIn time.Duration
+	keys      *KeyRing
+	// default aud of issued tokens
+	audience   []string
+	validation Validation
+	now        func() time.Time
+	// nil - access tokens are only signed
+	encryption *Encryption
 }
 
-func NewJWTManager(issuer string, expiresIn time.Duration, publicKeyPEM, privateKeyPEM []byte) (*JWTManager, error) {
-	pubBlock, _ := pem.Decode(publicKeyPEM)
-	if pubBlock == nil || pubBlock.Type != "PUBLIC KEY" {
+// NewJWTManager creates manager with a single key pair.
+func NewJWTManager(issuer string, expiresIn time.Duration, publicKeyPEM, privateKeyPEM []byte, opts ...Option) (*JWTManager, error) {
+	key, err := ParseKey("", "", publicKeyPEM, privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if key.PrivateKey == nil {
 		return nil, ErrKeyParsing
 	}
+	keys, err := NewKeyRing(key)
+	if err != nil {
+		return nil, err
+	}
+	return NewJWTManagerWithKeys(issuer, expiresIn, keys, opts...), nil
+}
+
+// NewJWTManagerWithKeys creates manager that signs with the active key of the ring
+// and accepts tokens of any key still in its overlap window.
+func NewJWTManagerWithKeys(issuer string, expiresIn time.Duration, keys *KeyRing, opts ...Option) *JWTManager {
+	j := &JWTManager{
+		issuer:    issuer,
+		expiresIn: expiresIn,
+		keys:      keys,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
 
-	pubKey, err := x509.ParsePKIXPublicKey(pubBlock.Bytes)
+// IssueToken signs access token. Issuer, issue time and jti are always set by the manager,
+// expiration - unless claims have it already.
+func (j *JWTManager) IssueToken(claims Claims) (string, error) {
+	claims, err := j.prepare(claims)
 	if err != nil {
-		return nil, ErrKeyParsing
+		return "", err
 	}
 
-	edPubKey, ok := pubKey.(ed25519.PublicKey)
-	if !ok {
-		return nil, ErrKeyParsing
+	signed, err := j.sign(claims)
+	if err != nil || j.encryption == nil {
+		return signed, err
 	}
+	encrypted, err := j.encrypt(signed)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+	return encrypted, nil
+}
 
-	privBlock, _ := pem.Decode(privateKeyPEM)
-	if privBlock == nil || privBlock.Type != "PRIVATE KEY" {
-		return nil, ErrKeyParsing
+// prepare fills claims set by the manager regardless of token format.
+func (j *JWTManager) prepare(claims Claims) (Claims, error) {
+	if err := claims.validateCustom(); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrTokenGeneration, err)
 	}
-	privKey, err := x509.ParsePKCS8PrivateKey(privBlock.Bytes)
+	id, err := newTokenID()
 	if err != nil {
-		return nil, ErrKeyParsing
+		return Claims{}, fmt.Errorf("%w: %s", ErrTokenGeneration, err)
 	}
 
-	edPrivKey, ok := privKey.(ed25519.PrivateKey)
-	if !ok {
-		return nil, ErrKeyParsing
+	now := j.now()
+	ttl := j.expiresIn
+	if claims.ttl > 0 {
+		ttl = claims.ttl
+	}
+	claims.Issuer = j.issuer
+	claims.ID = id
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	if len(claims.Audience) == 0 {
+		claims.Audience = j.audience
 	}
+	if claims.ExpiresAt == nil {
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+	}
+	return claims, nil
+}
 
-	return &JWTManager{
-		issuer:     issuer,
-		expiresIn:  expiresIn,
-		publicKey:  edPubKey,
-		privateKey: edPrivKey,
-	}, nil
+// sign signs claims with the active key and sets its kid header.
+func (j *JWTManager) sign(claims jwt.Claims) (string, error) {
+	key := j.keys.signingKey()
+	method, err := signingMethod(key.Algorithm)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrSigning, err)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrSigning, err)
+	}
+	signature, err := signWith(key.PrivateKey, key.Algorithm, []byte(signingString))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrSigning, err)
+	}
+	return signingString + "." + token.EncodeSegment(signature), nil
 }
 
-func (j *JWTManager) IssueToken(userID string) (string, error) {
-	claims := jwt.RegisteredClaims{
-		Issuer:    j.issuer,
-		Subject:   userID,
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiresIn)),
+// keyFunc selects verification key by kid header. Algorithm of the token has to match the key,
+// so a ring with keys of several algorithms accepts each of them only with its own.
+func (j *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	keys, err := j.keys.verificationKeys(kid, token.Method.Alg(), j.now())
+	if err != nil {
+		return nil, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	set := jwt.VerificationKeySet{}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, k.PublicKey)
+	}
+	return set, nil
+}
 
-	signed, err := token.SignedString(j.privateKey)
+// VerifyToken decrypts nested tokens, checks signature, issuer, time based claims and the rest of configured Validation,
+// returns claims of the access token.
+func (j *JWTManager) VerifyToken(tokenString string) (Claims, error) {
+	signed, err := j.decrypt(tokenString)
 	if err != nil {
-		return "", fmt.Errorf("%w: %s", ErrSigning, err)
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
 	}
-	return signed, nil
+
+	claims := Claims{}
+	if _, err := jwt.ParseWithClaims(signed, &claims, j.keyFunc, j.parserOptions()...); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+	if err := j.validate(claims); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	// one-time tokens are signed with the same key, they must not work as access tokens
+	if _, ok := claims.Custom["purpose"]; ok {
+		return Claims{}, fmt.Errorf("%w: one-time token", ErrValidation)
+	}
+
+	return claims, nil
 }
 
-func (j *JWTManager) VerifyToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
-			return nil, ErrValidation
-		}
-		return j.publicKey, nil
-	})
+// SignPayload signs arbitrary data with the active signing key, e.g. audit log checkpoints.
+func (j *JWTManager) SignPayload(data []byte) ([]byte, error) {
+	key := j.keys.signingKey()
+	signature, err := signWith(key.PrivateKey, key.Algorithm, data)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrValidation, err)
+		return nil, fmt.Errorf("%w: %s", ErrSigning, err)
 	}
+	return signature, nil
+}
 
-	return token, nil
+// VerifyPayload checks signature made by SignPayload with any key of the ring.
+// Overlap windows don't apply: old checkpoints stay verifiable after rotation.
+func (j *JWTManager) VerifyPayload(data, signature []byte) bool {
+	for _, k := range j.keys.keys {
+		method, err := signingMethod(k.Algorithm)
+		if err != nil {
+			continue
+		}
+		if method.Verify(string(data), signature, k.PublicKey) == nil {
+			return true
+		}
+	}
+	return false
 }
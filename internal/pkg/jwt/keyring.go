@@ -0,0 +1,159 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/pkg/signer"
+)
+
+// Key - signing key pair identified by kid.
+type Key struct {
+	ID        string
+	Algorithm string // one of Alg* constants
+	PublicKey crypto.PublicKey
+	// nil for keys kept only to verify tokens issued before rotation
+	PrivateKey Signer
+	// end of the overlap window: tokens signed with the key are rejected after it, zero - no limit
+	VerifyUntil time.Time
+}
+
+// ParseKey reads PEM encoded key pair, privateKeyPEM may be empty for verification-only keys.
+// Empty id is replaced with a thumbprint of the public key, empty algorithm is picked by key type.
+func ParseKey(id, algorithm string, publicKeyPEM, privateKeyPEM []byte) (Key, error) {
+	pub, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return Key{}, err
+	}
+	var priv Signer
+	if len(privateKeyPEM) > 0 {
+		if priv, err = signer.ParsePEM(privateKeyPEM); err != nil {
+			return Key{}, fmt.Errorf("%w: %s", ErrKeyParsing, err)
+		}
+	}
+	return NewKey(id, algorithm, pub, priv)
+}
+
+// NewKey checks that the key pair fits the algorithm, priv may be nil for verification-only keys
+// or a signer of an external key service.
+func NewKey(id, algorithm string, pub crypto.PublicKey, priv Signer) (Key, error) {
+	var err error
+	if algorithm == "" {
+		if algorithm, err = defaultAlgorithm(pub); err != nil {
+			return Key{}, err
+		}
+	}
+	if err := checkAlgorithm(algorithm, pub); err != nil {
+		return Key{}, err
+	}
+	if priv != nil {
+		if err := checkAlgorithm(algorithm, priv.Public()); err != nil {
+			return Key{}, err
+		}
+	}
+
+	key := Key{ID: id, Algorithm: algorithm, PublicKey: pub, PrivateKey: priv}
+	if key.ID == "" {
+		if key.ID, err = thumbprint(pub); err != nil {
+			return Key{}, err
+		}
+	}
+	return key, nil
+}
+
+// thumbprint - raw ed25519 key or DER of other keys, hashed.
+func thumbprint(pub crypto.PublicKey) (string, error) {
+	raw, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrKeyParsing, err)
+		}
+		raw = der
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// KeyRing holds the active signing key and older keys still accepted for verification.
+type KeyRing struct {
+	active string
+	keys   map[string]Key
+	// thumbprint -> id: tokens issued in single key mode carry the thumbprint as kid
+	aliases map[string]string
+}
+
+func NewKeyRing(active Key, previous ...Key) (*KeyRing, error) {
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("%w: active key %s has no private key", ErrKeyParsing, active.ID)
+	}
+	keys := make(map[string]Key, len(previous)+1)
+	aliases := make(map[string]string, len(previous)+1)
+	for _, k := range append([]Key{active}, previous...) {
+		if _, ok := keys[k.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate kid %s", ErrKeyParsing, k.ID)
+		}
+		if _, err := signingMethod(k.Algorithm); err != nil {
+			return nil, err
+		}
+		tp, err := thumbprint(k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.ID] = k
+		aliases[tp] = k.ID
+	}
+	return &KeyRing{active: active.ID, keys: keys, aliases: aliases}, nil
+}
+
+func (r *KeyRing) signingKey() Key {
+	return r.keys[r.active]
+}
+
+// verificationKeys returns key with given kid, or every usable key of the algorithm for tokens
+// issued before kid was introduced.
+func (r *KeyRing) verificationKeys(kid, alg string, now time.Time) ([]Key, error) {
+	if kid != "" {
+		k, ok := r.keys[kid]
+		if !ok {
+			k, ok = r.keys[r.aliases[kid]]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		if !k.VerifyUntil.IsZero() && now.After(k.VerifyUntil) {
+			return nil, fmt.Errorf("key %q is retired", kid)
+		}
+		if k.Algorithm != alg {
+			return nil, fmt.Errorf("key %q is not for %s", kid, alg)
+		}
+		return []Key{k}, nil
+	}
+
+	var keys []Key
+	for _, k := range r.usableKeys(now) {
+		if k.Algorithm == alg {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys for %s", alg)
+	}
+	return keys, nil
+}
+
+// usableKeys - active key first, then keys still in their overlap window.
+func (r *KeyRing) usableKeys(now time.Time) []Key {
+	keys := []Key{r.signingKey()}
+	for id, k := range r.keys {
+		if id != r.active && (k.VerifyUntil.IsZero() || !now.After(k.VerifyUntil)) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,67 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// oneTimeClaims - claims of short-lived tokens bound to a single purpose (e.g. magic-link login).
+// Purpose is what keeps them from being accepted as access tokens and vice versa.
+type oneTimeClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// IssueOneTimeToken returns signed token and its unique id (jti).
+// Single use is up to the caller: it has to remember consumed ids.
+func (j *JWTManager) IssueOneTimeToken(subject, purpose string, ttl time.Duration) (string, string, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+
+	now := j.now()
+	claims := oneTimeClaims{
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    j.issuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	signed, err := j.sign(claims)
+	if err != nil {
+		return "", "", err
+	}
+	return signed, id, nil
+}
+
+// VerifyOneTimeToken checks signature, expiration and purpose. Returns subject and token id.
+func (j *JWTManager) VerifyOneTimeToken(tokenString, purpose string) (string, string, error) {
+	claims := &oneTimeClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, j.keyFunc, j.parserOptions()...)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	if claims.Purpose != purpose || claims.ID == "" {
+		return "", "", fmt.Errorf("%w: unexpected token purpose", ErrValidation)
+	}
+
+	return claims.Subject, claims.ID, nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/pkg/paseto"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenIssuer - format of access tokens: JWT (JWTManager) or PASETO (PasetoIssuer).
+type TokenIssuer interface {
+	IssueToken(claims Claims) (string, error)
+	VerifyToken(token string) (Claims, error)
+}
+
+// PasetoIssuer issues access tokens as PASETO v4.public signed with Ed25519 keys of the manager.
+// Claims, validation and key rotation are the same as for JWT, kid is put into the footer.
+type PasetoIssuer struct {
+	j *JWTManager
+}
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// time claims are ISO 8601 strings in PASETO
+var pasetoTimeClaims = []string{"exp", "nbf", "iat"}
+
+func NewPasetoIssuer(j *JWTManager) (*PasetoIssuer, error) {
+	if alg := j.keys.signingKey().Algorithm; alg != AlgEdDSA {
+		return nil, fmt.Errorf("%w: paseto v4 needs Ed25519 key, active key is %s", ErrKeyParsing, alg)
+	}
+	return &PasetoIssuer{j: j}, nil
+}
+
+func (p *PasetoIssuer) IssueToken(claims Claims) (string, error) {
+	claims, err := p.j.prepare(claims)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+	for _, name := range pasetoTimeClaims {
+		if v, ok := payload[name].(float64); ok {
+			payload[name] = time.Unix(int64(v), 0).UTC().Format(time.RFC3339)
+		}
+	}
+	if aud, ok := payload["aud"].([]any); ok && len(aud) == 1 {
+		payload["aud"] = aud[0]
+	}
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+
+	key := p.j.keys.signingKey()
+	footer, err := json.Marshal(pasetoFooter{KeyID: key.ID})
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTokenGeneration, err)
+	}
+	token, err := paseto.SignV4(key.PrivateKey, message, footer, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrSigning, err)
+	}
+	return token, nil
+}
+
+func (p *PasetoIssuer) VerifyToken(token string) (Claims, error) {
+	message, err := p.verify(token)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+	for _, name := range pasetoTimeClaims {
+		v, ok := payload[name]
+		if !ok {
+			continue
+		}
+		s, _ := v.(string)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return Claims{}, fmt.Errorf("%w: invalid %s claim", ErrValidation, name)
+		}
+		payload[name] = t.Unix()
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+	claims := Claims{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	if err := jwt.NewValidator(p.j.parserOptions()...).Validate(claims); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+	if err := p.j.validate(claims); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+	return claims, nil
+}
+
+// verify picks keys by kid from the footer and returns the signed message.
+func (p *PasetoIssuer) verify(token string) ([]byte, error) {
+	rawFooter, err := paseto.FooterV4(token)
+	if err != nil {
+		return nil, err
+	}
+	var footer pasetoFooter
+	if len(rawFooter) > 0 {
+		if err := json.Unmarshal(rawFooter, &footer); err != nil {
+			return nil, fmt.Errorf("invalid footer")
+		}
+	}
+
+	keys, err := p.j.keys.verificationKeys(footer.KeyID, AlgEdDSA, p.j.now())
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if message, err := paseto.VerifyV4(k.PublicKey.(ed25519.PublicKey), token, nil); err == nil {
+			return message, nil
+		}
+	}
+	return nil, paseto.ErrInvalidSignature
+}
@@ -0,0 +1,58 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+)
+
+// Signer - private key of a Key. Any crypto.Signer works, so the key may live in a file, env or Vault,
+// see package signer.
+type Signer = crypto.Signer
+
+// signWith signs data according to alg. Unlike jwt library methods it doesn't need concrete key types,
+// only crypto.Signer.
+func signWith(signer Signer, alg string, data []byte) ([]byte, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("key has no signer")
+	}
+	if alg == AlgEdDSA {
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(data)
+	switch alg {
+	case AlgRS256:
+		return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case AlgPS256:
+		return signer.Sign(rand.Reader, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256})
+	case AlgES256:
+		der, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsaRaw(der, 32)
+	}
+	return nil, fmt.Errorf("unsupported algorithm %q", alg)
+}
+
+// ecdsaRaw converts ASN.1 signature crypto.Signer returns into r || s JWS expects.
+func ecdsaRaw(der []byte, size int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) > 0 {
+		return nil, fmt.Errorf("invalid ecdsa signature")
+	}
+	if sig.R.BitLen() > size*8 || sig.S.BitLen() > size*8 {
+		return nil, fmt.Errorf("invalid ecdsa signature")
+	}
+	raw := make([]byte, 2*size)
+	sig.R.FillBytes(raw[:size])
+	sig.S.FillBytes(raw[size:])
+	return raw, nil
+}
@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Validation - checks VerifyToken makes after the signature.
+type Validation struct {
+	// expected iss, empty - issuer of the manager
+	Issuer string
+	// token has to be issued for at least one of them, empty - aud isn't checked
+	Audiences []string
+	// clock skew tolerated in exp, nbf, iat and max age checks
+	Leeway time.Duration
+	// claims that must be present, e.g. "jti", "aud" or a custom one; exp is always required
+	RequiredClaims []string
+	// tokens issued earlier are rejected regardless of exp, zero - no limit
+	MaxAge time.Duration
+}
+
+// Option configures optional JWTManager behaviour.
+type Option func(*JWTManager)
+
+func WithValidation(v Validation) Option {
+	return func(j *JWTManager) {
+		j.validation = v
+	}
+}
+
+// WithClock replaces time.Now, e.g. to test expiration without sleeping.
+func WithClock(now func() time.Time) Option {
+	return func(j *JWTManager) {
+		j.now = now
+	}
+}
+
+// WithAudience sets aud of issued tokens that don't have their own.
+func WithAudience(audience ...string) Option {
+	return func(j *JWTManager) {
+		j.audience = audience
+	}
+}
+
+// parserOptions - checks jwt library does itself, shared by access and one-time tokens.
+func (j *JWTManager) parserOptions() []jwt.ParserOption {
+	issuer := j.validation.Issuer
+	if issuer == "" {
+		issuer = j.issuer
+	}
+	return []jwt.ParserOption{
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(j.validation.Leeway),
+		jwt.WithTimeFunc(j.now),
+	}
+}
+
+// validate runs checks the jwt library can't do: any of several audiences, required claims and max age.
+func (j *JWTManager) validate(claims Claims) error {
+	if len(j.validation.Audiences) > 0 && !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(j.validation.Audiences, aud)
+	}) {
+		return fmt.Errorf("token is not issued for accepted audiences")
+	}
+
+	for _, name := range j.validation.RequiredClaims {
+		if !claims.has(name) {
+			return fmt.Errorf("token has no %s claim", name)
+		}
+	}
+
+	if j.validation.MaxAge > 0 {
+		if claims.IssuedAt == nil {
+			return fmt.Errorf("token has no iat claim")
+		}
+		if j.now().Sub(claims.IssuedAt.Time) > j.validation.MaxAge+j.validation.Leeway {
+			return fmt.Errorf("token is older than %s", j.validation.MaxAge)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"strings"
+)
+
+// LogNotifier writes messages to the log instead of delivering them. Handy for local development.
+type LogNotifier struct {
+	log *slog.Logger
+}
+
+func NewLogNotifier(log *slog.Logger) LogNotifier {
+	return LogNotifier{log: log}
+}
+
+func (n LogNotifier) Send(ctx context.Context, to, subject, body string) error {
+	n.log.InfoContext(ctx, "notification", slog.String("to", to), slog.String("subject", subject), slog.String("body", body))
+	return nil
+}
+
+// SMTPNotifier delivers messages as plain-text emails.
+type SMTPNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPNotifier(host string, port int, username, password, from string) SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return SMTPNotifier{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (n SMTPNotifier) Send(_ context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("smtp send: invalid header value")
+	}
+
+	msg := strings.Join([]string{
+		"From: " + n.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Официальные тест-векторы 4-S-1 и 4-S-2 из paseto-standard/test-vectors
+func TestV4PublicVectors(t *testing.T) {
+	secret, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a37741eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+	key := ed25519.PrivateKey(secret)
+	message := []byte(`{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`)
+
+	tests := []struct {
+		name     string
+		footer   []byte
+		expected string
+	}{
+		{
+			name:     "4-S-1",
+			expected: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA",
+		},
+		{
+			name:     "4-S-2",
+			footer:   []byte(`{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`),
+			expected: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9v3Jt8mx_TdM2ceTGoqwrh4yDFn0XsHvvV_D0DtwQxVrJEBMl0F2caAdgnpKlt4p7xBnx1HcO-SPo8FPp214HDw.eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := SignV4(key, message, tt.footer, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+
+			verified, err := VerifyV4(key.Public().(ed25519.PublicKey), tt.expected, nil)
+			require.NoError(t, err)
+			assert.Equal(t, message, verified)
+		})
+	}
+}
+
+// Подпись покрывает footer и implicit assertion
+func TestV4PublicTampering(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	token, err := SignV4(priv, []byte(`{"sub":"user123"}`), []byte(`{"kid":"a"}`), []byte("aud"))
+	require.NoError(t, err)
+
+	_, err = VerifyV4(pub, token, []byte("aud"))
+	assert.NoError(t, err)
+	_, err = VerifyV4(pub, token, []byte("other"))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	footer, err := FooterV4(token)
+	require.NoError(t, err)
+	assert.Equal(t, `{"kid":"a"}`, string(footer))
+	forged := token[:len(token)-2] + "Yi"
+	_, err = VerifyV4(pub, forged, []byte("aud"))
+	assert.Error(t, err)
+
+	_, err = VerifyV4(pub, "v2.public."+token[len(headerV4Public):], nil)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,67 @@
+// Package signer provides private keys for token signing that don't have to be read from disk by config:
+// PEM from a file or an environment variable, or a key that never leaves HashiCorp Vault (transit engine).
+// All of them are crypto.Signer: Ed25519 keys sign the message itself (opts.HashFunc() == 0),
+// RSA and ECDSA keys sign a digest, ECDSA signatures are ASN.1 encoded.
+package signer
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid private key")
+
+// ParsePEM accepts PKCS#8 ("PRIVATE KEY"), PKCS#1 ("RSA PRIVATE KEY") and SEC 1 ("EC PRIVATE KEY") blocks,
+// the formats openssl produces by default.
+func ParsePEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return signer, nil
+}
+
+func FromFile(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePEM(data)
+}
+
+// FromEnv reads PEM from the variable, "\n" escapes are allowed for environments without multiline values.
+func FromEnv(name string) (crypto.Signer, error) {
+	data := os.Getenv(name)
+	if data == "" {
+		return nil, fmt.Errorf("env %s is empty", name)
+	}
+	return ParsePEM([]byte(strings.ReplaceAll(data, `\n`, "\n")))
+}
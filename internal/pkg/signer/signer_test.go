@@ -0,0 +1,199 @@
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ключ из переменной окружения, в том числе с экранированными переводами строк
+func TestFromEnv(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	for name, value := range map[string]string{
+		"multiline": pemKey,
+		"escaped":   strings.ReplaceAll(pemKey, "\n", `\n`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TEST_SIGNING_KEY", value)
+			signer, err := FromEnv("TEST_SIGNING_KEY")
+			require.NoError(t, err)
+			assert.Equal(t, priv.Public(), signer.Public())
+		})
+	}
+
+	t.Setenv("TEST_SIGNING_KEY", "")
+	_, err = FromEnv("TEST_SIGNING_KEY")
+	assert.Error(t, err)
+}
+
+// fakeTransit - минимальная реализация transit API: чтение ключа и подпись
+func fakeTransit(t *testing.T, name string, key crypto.Signer) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/transit/keys/" + name:
+			var publicKey string
+			if pub, ok := key.Public().(ed25519.PublicKey); ok {
+				publicKey = base64.StdEncoding.EncodeToString(pub)
+			} else {
+				der, err := x509.MarshalPKIXPublicKey(key.Public())
+				require.NoError(t, err)
+				publicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"latest_version": 2,
+				"keys":           map[string]any{"2": map[string]string{"public_key": publicKey}},
+			}})
+		case "/v1/transit/sign/" + name:
+			var req struct {
+				Input              string `json:"input"`
+				Prehashed          bool   `json:"prehashed"`
+				SignatureAlgorithm string `json:"signature_algorithm"`
+				KeyVersion         int    `json:"key_version"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			// ключ уже повернут в Vault, но подписываем версией, опубликованной при старте
+			assert.Equal(t, 2, req.KeyVersion)
+			input, err := base64.StdEncoding.DecodeString(req.Input)
+			require.NoError(t, err)
+
+			var opts crypto.SignerOpts = crypto.Hash(0)
+			if req.Prehashed {
+				opts = crypto.SHA256
+			}
+			if req.SignatureAlgorithm == "pss" {
+				opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+			}
+			signature, err := key.Sign(rand.Reader, input, opts)
+			require.NoError(t, err)
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{
+				"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(signature),
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// Подписи Vault проверяются открытым ключом, полученным из того же Vault
+func TestVaultTransit(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	message := []byte("header.payload")
+	digest := sha256.Sum256(message)
+	pss := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+
+	tests := []struct {
+		name   string
+		key    crypto.Signer
+		input  []byte
+		opts   crypto.SignerOpts
+		verify func(t *testing.T, signature []byte) bool
+	}{
+		{
+			name:  "Ed25519",
+			key:   edKey,
+			input: message,
+			opts:  crypto.Hash(0),
+			verify: func(t *testing.T, signature []byte) bool {
+				return ed25519.Verify(edKey.Public().(ed25519.PublicKey), message, signature)
+			},
+		},
+		{
+			name:  "ECDSA P-256",
+			key:   ecKey,
+			input: digest[:],
+			opts:  crypto.SHA256,
+			verify: func(t *testing.T, signature []byte) bool {
+				return ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], signature)
+			},
+		},
+		{
+			name:  "RSA PKCS#1 v1.5",
+			key:   rsaKey,
+			input: digest[:],
+			opts:  crypto.SHA256,
+			verify: func(t *testing.T, signature []byte) bool {
+				return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature) == nil
+			},
+		},
+		{
+			name:  "RSA PSS",
+			key:   rsaKey,
+			input: digest[:],
+			opts:  pss,
+			verify: func(t *testing.T, signature []byte) bool {
+				return rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature, pss) == nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeTransit(t, "jwt", tt.key)
+			defer server.Close()
+
+			vault, err := NewVaultTransit(context.Background(), VaultConfig{Address: server.URL, Token: "test-token", Key: "jwt"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.key.Public(), vault.Public())
+
+			signature, err := vault.Sign(rand.Reader, tt.input, tt.opts)
+			require.NoError(t, err)
+			assert.True(t, tt.verify(t, signature))
+		})
+	}
+
+	server := fakeTransit(t, "jwt", edKey)
+	defer server.Close()
+	_, err = NewVaultTransit(context.Background(), VaultConfig{Address: server.URL, Token: "wrong", Key: "jwt"})
+	assert.ErrorContains(t, err, "403")
+	_, err = NewVaultTransit(context.Background(), VaultConfig{Address: server.URL, Token: "test-token", Key: "missing"})
+	assert.ErrorContains(t, err, "404")
+
+	// зависший Vault не блокирует выдачу токенов дольше таймаута
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/transit/sign/") {
+			<-release
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer hanging.Close()
+	defer close(release)
+	vault, err := NewVaultTransit(context.Background(), VaultConfig{Address: hanging.URL, Token: "test-token", Key: "jwt", Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	started := time.Now()
+	_, err = vault.Sign(rand.Reader, message, crypto.Hash(0))
+	assert.Error(t, err)
+	assert.Less(t, time.Since(started), time.Second)
+}
@@ -0,0 +1,164 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VaultConfig - key of the transit secrets engine, https://developer.hashicorp.com/vault/api-docs/secret/transit
+type VaultConfig struct {
+	Address string
+	Token   string
+	// mount path of the engine, "transit" by default
+	Mount   string
+	Key     string
+	Timeout time.Duration
+}
+
+// VaultTransit signs with the version of a transit key that was the latest on startup, so signatures
+// always match the public key and kid published for it. The private key never leaves Vault.
+type VaultTransit struct {
+	cfg     VaultConfig
+	client  *http.Client
+	public  crypto.PublicKey
+	version int
+}
+
+// NewVaultTransit fetches the public key, so the key type and its availability are checked on startup.
+func NewVaultTransit(ctx context.Context, cfg VaultConfig) (*VaultTransit, error) {
+	if cfg.Mount == "" {
+		cfg.Mount = "transit"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	v := &VaultTransit{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+
+	var resp struct {
+		Data struct {
+			LatestVersion int `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := v.do(ctx, http.MethodGet, "keys/"+url.PathEscape(cfg.Key), nil, &resp); err != nil {
+		return nil, err
+	}
+	key, ok := resp.Data.Keys[strconv.Itoa(resp.Data.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("vault key %s: no public key of version %d", cfg.Key, resp.Data.LatestVersion)
+	}
+	public, err := parseVaultPublicKey(key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("vault key %s: %w", cfg.Key, err)
+	}
+	v.public = public
+	v.version = resp.Data.LatestVersion
+	return v, nil
+}
+
+// parseVaultPublicKey - Vault returns PEM for RSA and ECDSA keys and raw base64 for Ed25519.
+func parseVaultPublicKey(s string) (crypto.PublicKey, error) {
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("unsupported public key")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+func (v *VaultTransit) Public() crypto.PublicKey {
+	return v.public
+}
+
+func (v *VaultTransit) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	req := map[string]any{"input": base64.StdEncoding.EncodeToString(digest), "key_version": v.version}
+	switch v.public.(type) {
+	case ed25519.PublicKey:
+		if opts.HashFunc() != 0 {
+			return nil, fmt.Errorf("ed25519 signs the message, not a digest")
+		}
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		if opts.HashFunc() != crypto.SHA256 {
+			return nil, fmt.Errorf("unsupported hash %s", opts.HashFunc())
+		}
+		req["prehashed"] = true
+		req["hash_algorithm"] = "sha2-256"
+		if _, ok := v.public.(*rsa.PublicKey); ok {
+			req["signature_algorithm"] = "pkcs1v15"
+			if pss, ok := opts.(*rsa.PSSOptions); ok {
+				if pss.SaltLength != rsa.PSSSaltLengthEqualsHash {
+					return nil, fmt.Errorf("only PSS salt length equal to hash size is supported")
+				}
+				req["signature_algorithm"] = "pss"
+				req["salt_length"] = "hash"
+			}
+		}
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	// crypto.Signer has no context, the request is bound by the configured timeout
+	ctx, cancel := context.WithTimeout(context.Background(), v.cfg.Timeout)
+	defer cancel()
+	if err := v.do(ctx, http.MethodPost, "sign/"+url.PathEscape(v.cfg.Key), body, &resp); err != nil {
+		return nil, err
+	}
+	// vault:v<key version>:<base64>
+	parts := strings.SplitN(resp.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("vault: unexpected signature format")
+	}
+	if parts[1] != "v"+strconv.Itoa(v.version) {
+		return nil, fmt.Errorf("vault: signed with key version %s, expected v%d", parts[1], v.version)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func (v *VaultTransit) do(ctx context.Context, method, path string, body []byte, out any) error {
+	endpoint := strings.TrimRight(v.cfg.Address, "/") + "/v1/" + v.cfg.Mount + "/" + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.cfg.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault: %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
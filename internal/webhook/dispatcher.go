@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature - "sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with subscription secret
+	HeaderSignature = "X-Webhook-Signature"
+
+	batchSize       = 50
+	maxErrorLength  = 512
+	maxResponseRead = 4096
+)
+
+type Store interface {
+	ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error)
+	CreateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) (entity.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) error
+	FindWebhookDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error)
+	// DueWebhookDeliveries claims due deliveries for lease, other replicas don't get them meanwhile
+	DueWebhookDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+}
+
+type Config struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+}
+
+// Dispatcher persists deliveries for every matching subscription and sends them in background,
+// retrying failures with exponential backoff.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	cfg    Config
+	log    *slog.Logger
+}
+
+func NewDispatcher(store Store, cfg Config, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+// Payload - body of every webhook request
+type Payload struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// Enqueue records a delivery per subscription interested in event. Sending happens in Run.
+func (d *Dispatcher) Enqueue(ctx context.Context, event string, data interface{}) error {
+	subs, err := d.store.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(Payload{
+		ID:         id,
+		Type:       event,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		if !sub.Wants(event) {
+			continue
+		}
+		_, err := d.store.CreateWebhookDelivery(ctx, entity.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			Event:          event,
+			Payload:        body,
+			Status:         entity.DeliveryPending,
+			NextAttemptAt:  time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Redeliver queues a fresh copy of an earlier delivery, the original stays in the log untouched.
+func (d *Dispatcher) Redeliver(ctx context.Context, deliveryID int64) (entity.WebhookDelivery, error) {
+	orig, err := d.store.FindWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	return d.store.CreateWebhookDelivery(ctx, entity.WebhookDelivery{
+		SubscriptionID: orig.SubscriptionID,
+		Event:          orig.Event,
+		Payload:        orig.Payload,
+		Status:         entity.DeliveryPending,
+		NextAttemptAt:  time.Now(),
+	})
+}
+
+// Run polls for due deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DeliverDue(ctx); err != nil {
+				d.log.Error("webhook delivery", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// DeliverDue makes one attempt for every delivery whose time has come, up to batchSize of them.
+// Deliveries are claimed one at a time, so the lease has to cover a single request, not the whole batch.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	for i := 0; i < batchSize; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		due, err := d.store.DueWebhookDeliveries(ctx, time.Now(), 1, d.lease())
+		if err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		delivery := due[0]
+
+		sub, err := d.store.FindWebhookSubscription(ctx, delivery.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		d.attempt(ctx, sub, &delivery)
+
+		if err := d.store.UpdateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lease - how long a claimed delivery is hidden from other replicas: the request timeout
+// and as much again for loading the subscription and saving the outcome.
+func (d *Dispatcher) lease() time.Duration {
+	return 2 * d.cfg.Timeout
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, sub entity.WebhookSubscription, delivery *entity.WebhookDelivery) {
+	delivery.Attempts++
+	code, err := d.send(ctx, sub, *delivery)
+	delivery.ResponseCode = code
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = entity.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.Error = ""
+		return
+	}
+
+	delivery.Error = err.Error()
+	if len(delivery.Error) > maxErrorLength {
+		delivery.Error = delivery.Error[:maxErrorLength]
+	}
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = entity.DeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts, d.cfg.BackoffBase, d.cfg.BackoffMax))
+}
+
+func (d *Dispatcher) send(ctx context.Context, sub entity.WebhookSubscription, delivery entity.WebhookDelivery) (int, error) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, ts, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drain a bit so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseRead))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign computes HeaderSignature value. Receivers should recompute it and compare with hmac.Equal.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns delay before attempt number attempts+1: base, 2*base, 4*base... capped by max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// NewSecret generates random subscription secret.
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
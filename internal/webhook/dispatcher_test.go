@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bogatyr285/auth-go/internal/auth/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Проверяем подпись доставки и повторы с экспоненциальной задержкой
+func TestDispatcher(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		status = http.StatusInternalServerError
+		got    []*http.Request
+		bodies [][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	store := &memStore{}
+	store.subs = []entity.WebhookSubscription{
+		{ID: 1, URL: srv.URL, Secret: "s3cret", Events: []string{entity.EventUserRegistered}},
+		{ID: 2, URL: srv.URL, Secret: "other", Events: []string{entity.EventUserDeleted}},
+	}
+	d := NewDispatcher(store, Config{
+		Timeout:     time.Second,
+		MaxAttempts: 2,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Hour,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx := context.Background()
+	require.NoError(t, d.Enqueue(ctx, entity.EventUserRegistered, map[string]string{"username": "bob"}))
+	require.Len(t, store.deliveries, 1, "only the matching subscription gets a delivery")
+
+	// первая попытка неудачна - доставка откладывается
+	require.NoError(t, d.DeliverDue(ctx))
+	delivery := store.deliveries[0]
+	assert.Equal(t, entity.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseCode)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), delivery.NextAttemptAt, 5*time.Second)
+
+	require.Len(t, got, 1)
+	req := got[0]
+	assert.Equal(t, entity.EventUserRegistered, req.Header.Get(HeaderEvent))
+	assert.Equal(t, Sign("s3cret", req.Header.Get(HeaderTimestamp), bodies[0]), req.Header.Get(HeaderSignature))
+
+	// до истечения задержки повторной отправки нет
+	require.NoError(t, d.DeliverDue(ctx))
+	assert.Len(t, got, 1)
+
+	// последняя попытка тоже неудачна - доставка помечается как failed
+	store.deliveries[0].NextAttemptAt = time.Now()
+	require.NoError(t, d.DeliverDue(ctx))
+	assert.Equal(t, entity.DeliveryFailed, store.deliveries[0].Status)
+	assert.Equal(t, 2, store.deliveries[0].Attempts)
+
+	// повторная доставка создает новую запись с тем же телом
+	mu.Lock()
+	status = http.StatusNoContent
+	mu.Unlock()
+	redelivery, err := d.Redeliver(ctx, store.deliveries[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, d.DeliverDue(ctx))
+	redelivery = store.deliveries[redelivery.ID-1]
+	assert.Equal(t, entity.DeliveryDelivered, redelivery.Status)
+	assert.NotNil(t, redelivery.DeliveredAt)
+	assert.Equal(t, bodies[0], bodies[2])
+}
+
+// Две реплики рассылают пачку дольше аренды, но каждая доставка уходит один раз
+func TestDispatcherLeaseShorterThanBatch(t *testing.T) {
+	const deliveries = 10
+	var (
+		mu   sync.Mutex
+		sent = map[string]int{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(40 * time.Millisecond)
+		mu.Lock()
+		sent[r.Header.Get(HeaderDelivery)]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := &memStore{}
+	store.subs = []entity.WebhookSubscription{{ID: 1, URL: srv.URL, Secret: "s3cret", Events: []string{entity.EventUserRegistered}}}
+	cfg := Config{PollInterval: 10 * time.Millisecond, Timeout: 100 * time.Millisecond, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	first, second := NewDispatcher(store, cfg, log), NewDispatcher(store, cfg, log)
+	require.Less(t, first.lease(), deliveries*40*time.Millisecond, "one replica sends the batch longer than the lease")
+
+	ctx := context.Background()
+	for i := 0; i < deliveries; i++ {
+		require.NoError(t, first.Enqueue(ctx, entity.EventUserRegistered, map[string]int{"n": i}))
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, d := range []*Dispatcher{first, second} {
+		wg.Add(1)
+		go func(d *Dispatcher) {
+			defer wg.Done()
+			d.Run(runCtx)
+		}(d)
+	}
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		for _, d := range store.deliveries {
+			if d.Status != entity.DeliveryDelivered {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, sent, deliveries)
+	for id, n := range sent {
+		assert.Equal(t, 1, n, "delivery %s is sent once", id)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	assert.Equal(t, 30*time.Second, Backoff(1, base, max))
+	assert.Equal(t, time.Minute, Backoff(2, base, max))
+	assert.Equal(t, 4*time.Minute, Backoff(4, base, max))
+	assert.Equal(t, max, Backoff(10, base, max))
+}
+
+// memStore claims deliveries the way PostgresStorage does: a claimed delivery is due again after the lease
+type memStore struct {
+	mu         sync.Mutex
+	subs       []entity.WebhookSubscription
+	deliveries []entity.WebhookDelivery
+}
+
+func (s *memStore) ListWebhookSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return s.subs, nil
+}
+
+func (s *memStore) FindWebhookSubscription(ctx context.Context, id int64) (entity.WebhookSubscription, error) {
+	for _, sub := range s.subs {
+		if sub.ID == id {
+			return sub, nil
+		}
+	}
+	return entity.WebhookSubscription{}, entity.ErrNotFound
+}
+
+func (s *memStore) CreateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) (entity.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d.ID = int64(len(s.deliveries) + 1)
+	d.CreatedAt = time.Now()
+	s.deliveries = append(s.deliveries, d)
+	return d, nil
+}
+
+func (s *memStore) UpdateWebhookDelivery(ctx context.Context, d entity.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[d.ID-1] = d
+	return nil
+}
+
+func (s *memStore) FindWebhookDelivery(ctx context.Context, id int64) (entity.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || int(id) > len(s.deliveries) {
+		return entity.WebhookDelivery{}, entity.ErrNotFound
+	}
+	return s.deliveries[id-1], nil
+}
+
+func (s *memStore) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []entity.WebhookDelivery
+	for i, d := range s.deliveries {
+		if len(due) == limit {
+			break
+		}
+		if d.Status == entity.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+			s.deliveries[i].NextAttemptAt = now.Add(lease)
+		}
+	}
+	return due, nil
+}